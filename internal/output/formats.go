@@ -0,0 +1,227 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// yamlPlainRegex matches strings YAML reads back unchanged without quotes
+	yamlPlainRegex = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_./+()@-]*( [A-Za-z0-9_./+()@-]+)*$`)
+	// tomlBareKeyRegex matches keys TOML accepts without quotes
+	tomlBareKeyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	// yamlReserved are plain words YAML 1.1 readers turn into booleans or null
+	yamlReserved = map[string]bool{
+		"true": true, "false": true, "yes": true, "no": true, "on": true, "off": true,
+		"y": true, "n": true, "null": true,
+	}
+)
+
+// yamlScalar writes a scalar leaf, quoting strings that would read back as something else
+func yamlScalar(v any) string {
+	s, ok := v.(string)
+	switch {
+	case v == nil:
+		return "null"
+	case !ok:
+		return scalarText(v)
+	case yamlPlainRegex.MatchString(s) && !yamlReserved[strings.ToLower(s)]:
+		return s
+	}
+	return quoteString(s)
+}
+
+// writeYAML writes a field tree as block-style YAML indented by indent
+func writeYAML(sb *strings.Builder, value any, indent string) {
+	switch v := value.(type) {
+	case []field:
+		if len(v) == 0 {
+			sb.WriteString(indent + "{}\n")
+		}
+		for _, f := range v {
+			sb.WriteString(indent + yamlScalar(f.key) + ":")
+			writeYAMLChild(sb, f.value, indent)
+		}
+	case []any:
+		if len(v) == 0 {
+			sb.WriteString(indent + "[]\n")
+		}
+		for _, item := range v {
+			if !isEmptyTree(item) {
+				// Write the item one level in, then hang its first line off the dash
+				var child strings.Builder
+				writeYAML(&child, item, indent+"  ")
+				sb.WriteString(indent + "- " + strings.TrimPrefix(child.String(), indent+"  "))
+				continue
+			}
+			sb.WriteString(indent + "-")
+			writeYAMLChild(sb, item, indent)
+		}
+	default:
+		sb.WriteString(indent + yamlScalar(v) + "\n")
+	}
+}
+
+// writeYAMLChild finishes a "key:" or "-" line with a scalar or empty collection, or continues
+// with a nested block
+func writeYAMLChild(sb *strings.Builder, value any, indent string) {
+	switch v := value.(type) {
+	case []field:
+		if len(v) == 0 {
+			sb.WriteString(" {}\n")
+			return
+		}
+	case []any:
+		if len(v) == 0 {
+			sb.WriteString(" []\n")
+			return
+		}
+	default:
+		sb.WriteString(" " + yamlScalar(v) + "\n")
+		return
+	}
+	sb.WriteString("\n")
+	writeYAML(sb, value, indent+"  ")
+}
+
+// isEmptyTree reports whether a value is a scalar or an empty object or list
+func isEmptyTree(value any) bool {
+	switch v := value.(type) {
+	case []field:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	}
+	return true
+}
+
+func tomlKey(key string) string {
+	if tomlBareKeyRegex.MatchString(key) {
+		return key
+	}
+	return quoteString(key)
+}
+
+// isTableList reports whether a list holds only objects, so it is written as [[array]] tables
+func isTableList(value any) bool {
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return false
+	}
+	for _, item := range list {
+		if _, ok := item.([]field); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// tomlInline writes a value on one line; TOML has no null, so null becomes ""
+func tomlInline(value any) string {
+	switch v := value.(type) {
+	case []field:
+		parts := make([]string, 0, len(v))
+		for _, f := range v {
+			parts = append(parts, tomlKey(f.key)+" = "+tomlInline(f.value))
+		}
+		return "{ " + strings.Join(parts, ", ") + " }"
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = tomlInline(item)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case string:
+		return quoteString(v)
+	case nil:
+		return `""`
+	case json.Number:
+		// encoding/json writes large floats without a decimal point; TOML would read an integer
+		// past int64
+		if _, err := v.Int64(); err != nil && !strings.ContainsAny(v.String(), ".eE") {
+			return v.String() + ".0"
+		}
+	}
+	return scalarText(value)
+}
+
+// writeTOML writes an object as a TOML table at path: its plain keys first, as TOML requires,
+// then its sub-tables and arrays of tables. Null keys are left out.
+func writeTOML(sb *strings.Builder, fields []field, path string) {
+	var tables []field
+	for _, f := range fields {
+		_, object := f.value.([]field)
+		switch {
+		case f.value == nil:
+		case object || isTableList(f.value):
+			tables = append(tables, f)
+		default:
+			sb.WriteString(tomlKey(f.key) + " = " + tomlInline(f.value) + "\n")
+		}
+	}
+	for _, f := range tables {
+		key := tomlKey(f.key)
+		if path != "" {
+			key = path + "." + key
+		}
+		if object, ok := f.value.([]field); ok {
+			sb.WriteString("\n[" + key + "]\n")
+			writeTOML(sb, object, key)
+			continue
+		}
+		for _, item := range f.value.([]any) {
+			sb.WriteString("\n[[" + key + "]]\n")
+			writeTOML(sb, item.([]field), key)
+		}
+	}
+}
+
+// writeTable writes a header of the stable column set and a row per result, as CSV or as TSV with
+// tabs, newlines and backslashes escaped
+func writeTable(sb *strings.Builder, rows [][]field, tsv bool) {
+	columns := tableColumns(rows)
+	records := [][]string{columns}
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = rowValue(row, column)
+		}
+		records = append(records, record)
+	}
+
+	if !tsv {
+		w := csv.NewWriter(sb)
+		w.WriteAll(records)
+		return
+	}
+	escaper := strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+	for _, record := range records {
+		for i := range record {
+			record[i] = escaper.Replace(record[i])
+		}
+		sb.WriteString(strings.Join(record, "\t") + "\n")
+	}
+}
+
+// writeLogfmt writes a line of key=value pairs per result, quoting empty values and values with
+// spaces, equals signs, quotes or control characters
+func writeLogfmt(sb *strings.Builder, rows [][]field) {
+	for _, row := range rows {
+		var pairs []string
+		for _, f := range row {
+			if f.value == nil {
+				continue
+			}
+			value := scalarText(f.value)
+			// strconv.Quote changes only strings with quotes, backslashes or control characters
+			if value == "" || strings.ContainsAny(value, " =") || strconv.Quote(value) != `"`+value+`"` {
+				value = quoteString(value)
+			}
+			pairs = append(pairs, f.key+"="+value)
+		}
+		sb.WriteString(strings.Join(pairs, " ") + "\n")
+	}
+}
@@ -4,12 +4,29 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/zcyc/idinfo/internal/parsers"
 	"github.com/zcyc/idinfo/internal/types"
 )
 
+// displayLocation is the time zone timestamps are shown in
+var displayLocation = time.UTC
+
+// SetTimeZone sets the time zone timestamps are shown in; JSON output stays in UTC
+func SetTimeZone(loc *time.Location) {
+	if loc != nil {
+		displayLocation = loc
+	}
+}
+
+// displayTime formats a timestamp in the display time zone
+func displayTime(t time.Time, layout string) string {
+	return t.In(displayLocation).Format(layout)
+}
+
 // ShowCard displays the ID information in a card format
 func ShowCard(info *types.IDInfo) {
 	// Create the card
@@ -52,14 +69,18 @@ func ShowCard(info *types.IDInfo) {
 	if info.Entropy != nil {
 		fmt.Printf("┃ %-9s │ %-43s ┃\n", "Entropy", fmt.Sprintf("%d bits", *info.Entropy))
 	}
+	if info.Collision != nil {
+		fmt.Printf("┃ %-9s │ %-43s ┃\n", "Collision", info.Collision.Summary)
+	}
 
 	// Timestamp
 	if info.DateTime != nil {
-		timeStr := info.DateTime.Format(time.RFC3339)
+		timeStr := displayTime(*info.DateTime, time.RFC3339)
 		if info.Timestamp != nil {
 			timeStr = fmt.Sprintf("%s (%s)", *info.Timestamp, timeStr)
 		}
 		fmt.Printf("┃ %-9s │ %-43s ┃\n", "Timestamp", timeStr)
+		fmt.Printf("┃ %-9s │ %-43s ┃\n", "Age", parsers.FormatAge(*info.DateTime, time.Now()))
 	}
 
 	// Node information
@@ -82,6 +103,27 @@ func ShowCard(info *types.IDInfo) {
 		fmt.Printf("┃ %-9s │ %-43s ┃\n", "Sequence", "-")
 	}
 
+	// IDs found inside this one, e.g. JWT claims
+	if len(info.Nested) > 0 {
+		fmt.Println("┠───────────┼─────────────────────────────────────────────┨")
+		for _, nested := range info.Nested {
+			label, value := nestedSummary(nested)
+			fmt.Printf("┃ %-9s │ %-43s ┃\n", label, value)
+		}
+	}
+
+	// Other epochs a bare timestamp could count from
+	if readings := readingSummaries(info); len(readings) > 0 {
+		fmt.Println("┠───────────┼─────────────────────────────────────────────┨")
+		for i, reading := range readings {
+			label := ""
+			if i == 0 {
+				label = "Readings"
+			}
+			fmt.Printf("┃ %-9s │ %-43s ┃\n", label, reading)
+		}
+	}
+
 	fmt.Println("┠───────────┼─────────────────────────────────────────────┨")
 
 	// Show hex and binary representation
@@ -134,6 +176,36 @@ func ShowCard(info *types.IDInfo) {
 	fmt.Println("┗━━━━━━━━━━━┷━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━┛")
 }
 
+// readingSummaries lists the plausible interpretations of a timestamp, one card row each
+func readingSummaries(info *types.IDInfo) []string {
+	var rows []string
+	for _, reading := range info.Interpretations {
+		if reading.Score < 0.01 {
+			continue
+		}
+		rows = append(rows, fmt.Sprintf("%-10s %s %s", reading.Short, displayTime(reading.DateTime, time.RFC3339), parsers.FormatPercent(reading.Score)))
+	}
+	return rows
+}
+
+// nestedSummary returns the card label and value for a nested ID
+func nestedSummary(nested types.NestedID) (string, string) {
+	label := nested.Field
+	if len(label) > 9 {
+		label = label[:8] + "~"
+	}
+	value := nested.Info.IDType
+	if nested.Info.DateTime != nil {
+		// Drop the parenthesized detail so the date still fits the card
+		idType := strings.TrimSpace(strings.SplitN(value, "(", 2)[0])
+		value = fmt.Sprintf("%s %s", displayTime(*nested.Info.DateTime, time.RFC3339), idType)
+	}
+	if len(value) > 43 {
+		value = value[:40] + "..."
+	}
+	return label, value
+}
+
 // ShowShort displays a short one-line summary
 func ShowShort(info *types.IDInfo) {
 	if info.Version != "" {
@@ -191,9 +263,9 @@ func ShowComparison(results []*types.IDInfo) {
 
 	for _, ts := range timestamps {
 		prefix := "- "
-		suffix := ""
+		suffix := fmt.Sprintf(" (%s)", parsers.FormatAge(ts.timestamp, now))
 		if ts.future {
-			suffix = " (future)"
+			suffix = fmt.Sprintf(" (future, %s)", parsers.FormatAge(ts.timestamp, now))
 		}
 
 		// Check if this is around now
@@ -205,8 +277,418 @@ func ShowComparison(results []*types.IDInfo) {
 
 		fmt.Printf("%s%s %s%s\n",
 			prefix,
-			ts.timestamp.Format(time.RFC3339),
+			displayTime(ts.timestamp, time.RFC3339),
 			ts.format,
 			suffix)
 	}
 }
+
+// ShowCursor displays a decoded cursor as a tree, with the ID each leaf decodes to
+func ShowCursor(node *types.CursorNode) {
+	if len(node.Layers) > 0 {
+		fmt.Printf("Layers: %s\n", strings.Join(node.Layers, " → "))
+	}
+	fmt.Println(cursorLine(&types.CursorNode{Kind: node.Kind, Value: node.Value}))
+	for _, id := range node.IDs {
+		fmt.Printf("↳ %s\n", cursorIDSummary(id))
+	}
+	showCursorChildren(node, "")
+}
+
+func showCursorChildren(node *types.CursorNode, indent string) {
+	for i, child := range node.Children {
+		branch, next := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Printf("%s%s%s\n", indent, branch, cursorLine(child))
+		for _, id := range child.IDs {
+			summary := cursorIDSummary(id)
+			if id.Field != child.Key {
+				summary = id.Field + ": " + summary
+			}
+			fmt.Printf("%s%s↳ %s\n", indent, next, summary)
+		}
+		showCursorChildren(child, indent+next)
+	}
+}
+
+// cursorLine renders a node's key, value, kind and any layers peeled to reach it
+func cursorLine(node *types.CursorNode) string {
+	line := node.Key
+	if node.Value != "" {
+		value := node.Value
+		if len(value) > 64 {
+			value = value[:61] + "..."
+		}
+		if line != "" {
+			line += ": "
+		}
+		line += value
+	}
+	if line == "" {
+		return node.Kind
+	}
+	kind := node.Kind
+	if len(node.Layers) > 0 {
+		kind = strings.Join(node.Layers, " → ") + " → " + kind
+	}
+	return fmt.Sprintf("%s (%s)", line, kind)
+}
+
+// cursorIDSummary describes an ID found in a cursor leaf
+func cursorIDSummary(id types.NestedID) string {
+	summary := id.Info.IDType
+	if id.Info.Version != "" {
+		summary += ", " + id.Info.Version
+	}
+	if id.Info.DateTime != nil {
+		summary += ", " + displayTime(*id.Info.DateTime, time.RFC3339Nano)
+	}
+	if id.Info.Secret {
+		summary += ", " + id.Value
+	}
+	return summary
+}
+
+// ShowCollision displays a collision risk report; source names where the random bits came from
+func ShowCollision(risk *types.CollisionRisk, source string) {
+	threshold := parsers.FormatPercent(risk.Threshold)
+	bits := fmt.Sprintf("%d", risk.RandomBits)
+	if source != "" {
+		bits += " (" + source + ")"
+	}
+	fmt.Printf("%-24s %s\n", "Random bits:", bits)
+	capacity := parsers.FormatIDCount(risk.IDsAtThreshold)
+	if risk.Tick != "" {
+		capacity += " per " + risk.Tick
+	}
+	fmt.Printf("%-24s %s\n", "IDs at "+threshold+" risk:", capacity)
+	if risk.RatePerSecond > 0 {
+		fmt.Printf("%-24s %s IDs/second\n", "Rate:", strconv.FormatFloat(risk.RatePerSecond, 'g', 6, 64))
+		fmt.Printf("%-24s %s\n", "Time to "+threshold+" risk:", parsers.FormatSpan(risk.SecondsToThreshold))
+	}
+	if risk.Probability != nil {
+		count := parsers.FormatIDCount(risk.Count) + " IDs"
+		if risk.PeriodSeconds > 0 {
+			count += " (" + parsers.FormatSpan(risk.PeriodSeconds) + " at this rate)"
+		}
+		fmt.Printf("%-24s %s\n", "Generated:", count)
+		fmt.Printf("%-24s %s\n", "Collision probability:", strconv.FormatFloat(*risk.Probability, 'g', 3, 64))
+	}
+}
+
+// ShowAnalysis displays the randomness measurements of a corpus of IDs
+func ShowAnalysis(a *types.CorpusAnalysis) {
+	fmt.Printf("%-12s %s\n", "Format:", a.Format)
+	count := fmt.Sprintf("%d", a.Count)
+	if a.Skipped > 0 {
+		count += fmt.Sprintf(" (%d lines skipped)", a.Skipped)
+	}
+	fmt.Printf("%-12s %s\n", "IDs:", count)
+	fmt.Printf("%-12s %d\n", "Duplicates:", a.Duplicates)
+
+	entropy := fmt.Sprintf("%.1f bits by bit frequency, %.1f bits by character frequency", a.MeasuredBitEntropy, a.MeasuredCharEntropy)
+	if a.DeclaredEntropy != nil {
+		entropy = fmt.Sprintf("declared %d bits; measured %s", *a.DeclaredEntropy, entropy)
+	}
+	fmt.Printf("%-12s %s\n", "Entropy:", entropy)
+
+	if len(a.Bits) > 0 {
+		fmt.Println("\nBits (most significant first; . random, 0/1 fixed, b biased, r fails runs test):")
+		var row strings.Builder
+		for i, bit := range a.Bits {
+			if i%64 == 0 {
+				if row.Len() > 0 {
+					fmt.Println(row.String())
+				}
+				row.Reset()
+				row.WriteString(fmt.Sprintf("  %4d  ", i))
+			} else if i%8 == 0 {
+				row.WriteByte(' ')
+			}
+			row.WriteByte(bitSymbol(bit))
+		}
+		fmt.Println(row.String())
+	}
+
+	if len(a.Positions) > 0 {
+		fmt.Println("\nCharacters (* restricted by the format to the characters seen):")
+		fmt.Printf("  %4s  %8s  %7s  %10s  %8s\n", "pos", "distinct", "entropy", "chi-square", "p-value")
+		for _, pos := range a.Positions {
+			if pos.Distinct == 1 {
+				// Fixed is left empty for secrets
+				fixed := "fixed"
+				if pos.Fixed != "" {
+					fixed = "'" + pos.Fixed + "'"
+				}
+				fmt.Printf("  %4d  %8s  %7s  %10s  %8s\n", pos.Position, fixed, "-", "-", "-")
+				continue
+			}
+			chi, p := "-", "-"
+			if pos.DegreesOfFreedom > 0 {
+				chi = fmt.Sprintf("%.1f", pos.ChiSquare)
+				p = fmt.Sprintf("%.3g", pos.PValue)
+			}
+			distinct := fmt.Sprintf("%d", pos.Distinct)
+			if pos.Restricted {
+				distinct += "*"
+			}
+			fmt.Printf("  %4d  %8s  %7.2f  %10s  %8s\n", pos.Position, distinct, pos.Entropy, chi, p)
+		}
+	}
+
+	fmt.Println()
+	if len(a.Findings) == 0 {
+		fmt.Println("Findings: none, the IDs look as random as the format declares")
+		return
+	}
+	fmt.Println("Findings:")
+	for _, finding := range a.Findings {
+		fmt.Printf("  - %s\n", finding)
+	}
+}
+
+// bitSymbol summarizes one bit position for the bit map
+func bitSymbol(bit types.BitStat) byte {
+	switch {
+	case bit.Fixed && bit.Ones == 1:
+		return '1'
+	case bit.Fixed:
+		return '0'
+	case bit.RunsPValue < 0.001:
+		return 'r'
+	case bit.BiasPValue < 0.001:
+		return 'b'
+	}
+	return '.'
+}
+
+// ShowFleet displays the generators seen in a stream of IDs, busiest first
+func ShowFleet(report *types.FleetReport) {
+	fmt.Printf("%-12s %s\n", "Format:", report.Format)
+	count := fmt.Sprintf("%d", report.Count)
+	if report.Skipped > 0 {
+		count += fmt.Sprintf(" (%d lines skipped)", report.Skipped)
+	}
+	fmt.Printf("%-12s %s\n", "IDs:", count)
+	fmt.Printf("%-12s %d\n\n", "Generators:", len(report.Generators))
+
+	width := len("Generator")
+	names := make([]string, len(report.Generators))
+	for i, gen := range report.Generators {
+		names[i] = gen.Node1
+		if gen.Node2 != "" {
+			names[i] += " / " + gen.Node2
+		}
+		width = max(width, len(names[i]))
+	}
+	fmt.Printf("%-*s  %7s  %-20s  %-20s  %9s  %13s  %6s  %10s\n",
+		width, "Generator", "IDs", "First seen", "Last seen", "Rate/s", "Gaps (missing)", "Resets", "Skew")
+	for i, gen := range report.Generators {
+		first, last, rate, skew := "-", "-", "-", "-"
+		if gen.FirstSeen != nil {
+			first = displayTime(*gen.FirstSeen, "2006-01-02 15:04:05")
+			last = displayTime(*gen.LastSeen, "2006-01-02 15:04:05")
+		}
+		if gen.RatePerSecond > 0 {
+			rate = strconv.FormatFloat(gen.RatePerSecond, 'g', 4, 64)
+		}
+		if gen.ClockSkewMs != nil {
+			skew = (time.Duration(*gen.ClockSkewMs * float64(time.Millisecond))).Round(time.Millisecond).String()
+		}
+		gaps := fmt.Sprintf("%d (%d)", gen.CounterGaps, gen.MissingValues)
+		if gen.CounterConstant {
+			gaps = "constant"
+		} else if gen.CounterStep != "" {
+			gaps = "random step"
+		}
+		fmt.Printf("%-*s  %7d  %-20s  %-20s  %9s  %13s  %6d  %10s\n",
+			width, names[i], gen.Count, first, last, rate, gaps, gen.CounterResets, skew)
+	}
+
+	if len(report.Findings) > 0 {
+		fmt.Println("\nFindings:")
+		for _, finding := range report.Findings {
+			fmt.Printf("  - %s\n", finding)
+		}
+	}
+}
+
+// ShowAnomalies displays the problems found in a stream of IDs, one per line
+func ShowAnomalies(report *types.AnomalyReport) {
+	for _, a := range report.Findings {
+		fmt.Printf("line %d: %s: %s: %s\n", a.Line, a.Kind, a.ID, a.Message)
+	}
+	if len(report.Findings) == 0 {
+		fmt.Printf("%d %s IDs checked, no anomalies\n", report.Count, report.Format)
+		return
+	}
+
+	kinds := make([]string, 0, len(report.Counts))
+	for kind := range report.Counts {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	var counts []string
+	for _, kind := range kinds {
+		counts = append(counts, fmt.Sprintf("%d %s", report.Counts[kind], kind))
+	}
+	fmt.Printf("\n%d %s IDs checked: %s\n", report.Count, report.Format, strings.Join(counts, ", "))
+}
+
+// ShowDiff displays IDs side by side, marking fields that differ, how each relates to the first
+// ID, and the hex/binary view with differing bits underlined
+func ShowDiff(diff *types.IDDiff) {
+	width := len("Field")
+	for _, field := range diff.Fields {
+		width = max(width, len(field.Name))
+	}
+	columns := make([]int, len(diff.IDs))
+	for i := range diff.IDs {
+		columns[i] = len(fmt.Sprintf("#%d", i+1))
+		for _, field := range diff.Fields {
+			columns[i] = max(columns[i], len([]rune(field.Values[i])))
+		}
+	}
+
+	header := fmt.Sprintf("  %-*s", width, "Field")
+	for i := range diff.IDs {
+		header += fmt.Sprintf("  %-*s", columns[i], fmt.Sprintf("#%d", i+1))
+	}
+	fmt.Println(strings.TrimRight(header, " "))
+	for _, field := range diff.Fields {
+		marker := " "
+		if !field.Same {
+			marker = "≠"
+		}
+		row := fmt.Sprintf("%s %-*s", marker, width, field.Name)
+		for i, value := range field.Values {
+			if value == "" {
+				value = "-"
+			}
+			row += "  " + value + strings.Repeat(" ", columns[i]-len([]rune(value)))
+		}
+		fmt.Println(strings.TrimRight(row, " "))
+	}
+
+	fmt.Println()
+	for _, rel := range diff.Relations {
+		fmt.Printf("#%d vs #1: %s\n", rel.Index+1, rel.Summary)
+	}
+
+	for _, rel := range diff.Relations {
+		if rel.BitMask == nil || rel.DifferingBits == 0 {
+			continue
+		}
+		fmt.Printf("\nBits differing between #1 and #%d:\n", rel.Index+1)
+		base, other := diff.IDs[0].Binary, diff.IDs[rel.Index].Binary
+		for offset := 0; offset < len(base); offset += 4 {
+			end := min(offset+4, len(base))
+			var carets []string
+			for _, b := range rel.BitMask[offset:end] {
+				bits := strings.Map(func(r rune) rune {
+					if r == '1' {
+						return '^'
+					}
+					return ' '
+				}, fmt.Sprintf("%08b", b))
+				carets = append(carets, bits[:4]+" "+bits[4:])
+			}
+			fmt.Printf("  #1  %-9s │ %s\n", diffHex(base[offset:end]), diffBits(base[offset:end]))
+			fmt.Printf("  #%-2d %-9s │ %s\n", rel.Index+1, diffHex(other[offset:end]), diffBits(other[offset:end]))
+			if line := strings.TrimRight(strings.Join(carets, " "), " "); line != "" {
+				fmt.Printf("      %-9s │ %s\n", "", line)
+			}
+		}
+	}
+}
+
+// diffHex formats up to four bytes as two groups of hex digits, as the card does
+func diffHex(b []byte) string {
+	hex := fmt.Sprintf("%x", b)
+	if len(hex) > 4 {
+		hex = hex[:4] + " " + hex[4:]
+	}
+	return hex
+}
+
+// diffBits formats bytes as groups of four binary digits, as the card does
+func diffBits(b []byte) string {
+	var groups []string
+	for _, v := range b {
+		bits := fmt.Sprintf("%08b", v)
+		groups = append(groups, bits[:4], bits[4:])
+	}
+	return strings.Join(groups, " ")
+}
+
+// ShowTimeline lists IDs with their timestamps, one per line
+func ShowTimeline(ids []*types.TimedID) {
+	now := time.Now()
+	for _, id := range ids {
+		fmt.Printf("%-35s  %-14s  %s\n", displayTime(id.DateTime, time.RFC3339Nano), parsers.FormatAge(id.DateTime, now), id.ID)
+	}
+}
+
+// ShowHistogram draws one bar of '#' per bucket, scaled so the fullest bucket is width wide
+func ShowHistogram(h *types.Histogram, width int) {
+	layout := "2006-01-02 15:04"
+	if h.Bucket == "day" {
+		layout = "2006-01-02"
+	}
+	peak := 0
+	for _, bucket := range h.Buckets {
+		peak = max(peak, bucket.Count)
+	}
+	digits := len(strconv.Itoa(peak))
+	for _, bucket := range h.Buckets {
+		bar := 0
+		if peak > 0 {
+			bar = (bucket.Count*width + peak - 1) / peak
+		}
+		row := fmt.Sprintf("%s  %*d  %s", displayTime(bucket.Start, layout), digits, bucket.Count, strings.Repeat("#", bar))
+		fmt.Println(strings.TrimRight(row, " "))
+	}
+
+	summary := fmt.Sprintf("%d IDs in %d %s buckets", h.Count, len(h.Buckets), h.Bucket)
+	if h.Filtered > 0 {
+		summary += fmt.Sprintf(", %d outside the window", h.Filtered)
+	}
+	if h.Skipped > 0 {
+		summary += fmt.Sprintf(", %d lines without a timestamp skipped", h.Skipped)
+	}
+	if len(h.Buckets) > 0 {
+		fmt.Println()
+	}
+	fmt.Println(summary)
+}
+
+// ShowTimePrefixes lists the prefix each time-sortable format's IDs share at an instant, the
+// creation times that prefix matches, and the first and last possible ID for range queries
+func ShowTimePrefixes(report *types.TimePrefixReport) {
+	fmt.Printf("%-7s %s (%s)\n\n", "At:", displayTime(report.At, time.RFC3339Nano), parsers.FormatAge(report.At, time.Now()))
+
+	width, prefixWidth := len("Format"), len("Prefix")
+	for _, p := range report.Prefixes {
+		width = max(width, len(p.Format))
+		prefixWidth = max(prefixWidth, len(p.Prefix))
+	}
+	fmt.Printf("%-*s  %-*s  %s\n", width, "Format", prefixWidth, "Prefix", "Matches IDs created")
+	for _, p := range report.Prefixes {
+		if p.Error != "" {
+			fmt.Printf("%-*s  %-*s  %s\n", width, p.Format, prefixWidth, "-", p.Error)
+			continue
+		}
+		span := p.MatchesUntil.Sub(*p.MatchesFrom)
+		fmt.Printf("%-*s  %-*s  %s to %s (%s)\n", width, p.Format, prefixWidth, p.Prefix,
+			displayTime(*p.MatchesFrom, time.RFC3339Nano), displayTime(*p.MatchesUntil, time.RFC3339Nano), span)
+	}
+
+	fmt.Println("\nFirst and last possible ID at this instant:")
+	for _, p := range report.Prefixes {
+		if p.Error == "" {
+			fmt.Printf("%-*s  %s .. %s\n", width, p.Format, p.First, p.Last)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/zcyc/idinfo/internal/parsers"
 	"github.com/zcyc/idinfo/internal/types"
 
 	"github.com/fatih/color"
@@ -94,10 +95,17 @@ func ShowCardColored(info *types.IDInfo) {
 		valueColor.Printf("%-43s ", fmt.Sprintf("%d bits", *info.Entropy))
 		borderColor.Println("┃")
 	}
+	if info.Collision != nil {
+		borderColor.Print("┃ ")
+		labelColor.Printf("%-9s ", "Collision")
+		borderColor.Print("│ ")
+		valueColor.Printf("%-43s ", info.Collision.Summary)
+		borderColor.Println("┃")
+	}
 
 	// Timestamp
 	if info.DateTime != nil {
-		timeStr := info.DateTime.Format(time.RFC3339)
+		timeStr := displayTime(*info.DateTime, time.RFC3339)
 		if info.Timestamp != nil {
 			timeStr = fmt.Sprintf("%s (%s)", *info.Timestamp, timeStr)
 		}
@@ -109,6 +117,12 @@ func ShowCardColored(info *types.IDInfo) {
 		borderColor.Print("│ ")
 		valueColor.Printf("%-43s ", timeStr)
 		borderColor.Println("┃")
+
+		borderColor.Print("┃ ")
+		labelColor.Printf("%-9s ", "Age")
+		borderColor.Print("│ ")
+		valueColor.Printf("%-43s ", parsers.FormatAge(*info.DateTime, time.Now()))
+		borderColor.Println("┃")
 	}
 
 	// Node information
@@ -155,6 +169,35 @@ func ShowCardColored(info *types.IDInfo) {
 		borderColor.Println("┃")
 	}
 
+	// IDs found inside this one, e.g. JWT claims
+	if len(info.Nested) > 0 {
+		borderColor.Println("┠───────────┼─────────────────────────────────────────────┨")
+		for _, nested := range info.Nested {
+			label, value := nestedSummary(nested)
+			borderColor.Print("┃ ")
+			labelColor.Printf("%-9s ", label)
+			borderColor.Print("│ ")
+			valueColor.Printf("%-43s ", value)
+			borderColor.Println("┃")
+		}
+	}
+
+	// Other epochs a bare timestamp could count from
+	if readings := readingSummaries(info); len(readings) > 0 {
+		borderColor.Println("┠───────────┼─────────────────────────────────────────────┨")
+		for i, reading := range readings {
+			label := ""
+			if i == 0 {
+				label = "Readings"
+			}
+			borderColor.Print("┃ ")
+			labelColor.Printf("%-9s ", label)
+			borderColor.Print("│ ")
+			valueColor.Printf("%-43s ", reading)
+			borderColor.Println("┃")
+		}
+	}
+
 	borderColor.Println("┠───────────┼─────────────────────────────────────────────┨")
 
 	// Show hex and binary representation
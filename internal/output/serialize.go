@@ -0,0 +1,293 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// field is one key of a serialized object
+type field struct {
+	key   string
+	value any
+}
+
+// serializeFields turns a value into the tree every structured format is written from: objects
+// become []field in declaration order, lists []any, and scalars string, json.Number, bool or nil.
+// The tree is read back from encoding/json, so the json tags decide names, omitempty and
+// formatting, and a field added to types.IDInfo shows up in every format.
+func serializeFields(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return decodeFields(dec)
+}
+
+func decodeFields(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		fields := []field{}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeFields(dec)
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field{key.(string), value})
+		}
+		_, err = dec.Token()
+		return fields, err
+	case json.Delim('['):
+		list := []any{}
+		for dec.More() {
+			value, err := decodeFields(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = dec.Token()
+		return list, err
+	}
+	return tok, nil
+}
+
+// flattenFields lists the scalar leaves of an object under dotted keys; lists stay whole, as
+// compact JSON
+func flattenFields(prefix string, value any, out *[]field) {
+	switch v := value.(type) {
+	case []field:
+		for _, f := range v {
+			flattenFields(prefix+f.key+".", f.value, out)
+		}
+	case []any:
+		*out = append(*out, field{strings.TrimSuffix(prefix, "."), treeJSON(v)})
+	default:
+		*out = append(*out, field{strings.TrimSuffix(prefix, "."), v})
+	}
+}
+
+// scalarText writes a scalar leaf as plain text, with "" for null
+func scalarText(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case json.Number:
+		return s.String()
+	case bool:
+		return strconv.FormatBool(s)
+	}
+	return treeJSON(v)
+}
+
+// quoteString writes a JSON string literal, which YAML, TOML and logfmt all read as a quoted string
+func quoteString(s string) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(s)
+	// TOML wants DEL escaped as well
+	return strings.ReplaceAll(strings.TrimSuffix(buf.String(), "\n"), "\x7f", `\u007f`)
+}
+
+// treeJSON writes a field tree back as compact JSON
+func treeJSON(value any) string {
+	switch v := value.(type) {
+	case []field:
+		parts := make([]string, len(v))
+		for i, f := range v {
+			parts[i] = quoteString(f.key) + ":" + treeJSON(f.value)
+		}
+		return "{" + strings.Join(parts, ",") + "}"
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = treeJSON(item)
+		}
+		return "[" + strings.Join(parts, ",") + "]"
+	case string:
+		return quoteString(v)
+	case nil:
+		return "null"
+	}
+	return scalarText(value)
+}
+
+// typeColumns lists the dotted keys a struct flattens to, from its json tags and in declaration
+// order, whether or not a result sets them. Maps are left out; their keys differ per result.
+func typeColumns(t reflect.Type, prefix string) []string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	var columns []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Struct && ft != reflect.TypeOf(time.Time{}):
+			columns = append(columns, typeColumns(ft, prefix+name+".")...)
+		case ft.Kind() == reflect.Map:
+		default:
+			columns = append(columns, prefix+name)
+		}
+	}
+	return columns
+}
+
+// tableColumns is the column set of CSV and TSV output: every IDInfo field in declaration order,
+// then an extra.<key> column for each extra key of the results, sorted, so the header only
+// depends on the set of results
+func tableColumns(rows [][]field) []string {
+	columns := typeColumns(reflect.TypeOf(types.IDInfo{}), "")
+	seen := make(map[string]bool)
+	var keys []string
+	for _, row := range rows {
+		for _, f := range row {
+			if strings.HasPrefix(f.key, "extra.") && !seen[f.key] {
+				seen[f.key] = true
+				keys = append(keys, f.key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return append(columns, keys...)
+}
+
+// structuredRows flattens each result into dotted keys
+func structuredRows(results []*types.IDInfo) ([][]field, error) {
+	var rows [][]field
+	for _, info := range results {
+		tree, err := serializeFields(info)
+		if err != nil {
+			return nil, err
+		}
+		var row []field
+		flattenFields("", tree, &row)
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// rowValue looks up a dotted key in a flattened result
+func rowValue(row []field, key string) string {
+	for _, f := range row {
+		if f.key == key {
+			return scalarText(f.value)
+		}
+	}
+	return ""
+}
+
+// IsStructuredFormat reports whether -o names a format written by ShowStructured
+func IsStructuredFormat(format string) bool {
+	switch format {
+	case "json", "ndjson", "yaml", "yml", "toml", "csv", "tsv", "logfmt":
+		return true
+	}
+	return false
+}
+
+// ShowStructured writes results as JSON, NDJSON, YAML, TOML, CSV, TSV or logfmt. With list set
+// every result is written (a JSON array, a YAML sequence, TOML [[results]] tables); otherwise
+// only the first, as a single object.
+func ShowStructured(format string, results []*types.IDInfo, list bool) error {
+	text, err := structuredText(format, results, list)
+	if err != nil {
+		return err
+	}
+	fmt.Print(text)
+	return nil
+}
+
+// structuredText renders the output of ShowStructured
+func structuredText(format string, results []*types.IDInfo, list bool) (string, error) {
+	if !list {
+		results = results[:1]
+	}
+	var sb strings.Builder
+	switch format {
+	case "json":
+		var b []byte
+		var err error
+		if list {
+			b, err = json.MarshalIndent(results, "", "  ")
+		} else {
+			b, err = json.MarshalIndent(results[0], "", "  ")
+		}
+		if err != nil {
+			return "", err
+		}
+		sb.Write(b)
+		sb.WriteString("\n")
+	case "ndjson":
+		for _, info := range results {
+			b, err := json.Marshal(info)
+			if err != nil {
+				return "", err
+			}
+			sb.Write(b)
+			sb.WriteString("\n")
+		}
+	case "yaml", "yml", "toml":
+		var tree any
+		var err error
+		if list {
+			tree, err = serializeFields(results)
+		} else {
+			tree, err = serializeFields(results[0])
+		}
+		if err != nil {
+			return "", err
+		}
+		if format == "toml" {
+			if list {
+				tree = []field{{"results", tree}}
+			}
+			writeTOML(&sb, tree.([]field), "")
+		} else {
+			writeYAML(&sb, tree, "")
+		}
+	case "csv", "tsv", "logfmt":
+		rows, err := structuredRows(results)
+		if err != nil {
+			return "", err
+		}
+		if format == "logfmt" {
+			writeLogfmt(&sb, rows)
+		} else {
+			writeTable(&sb, rows, format == "tsv")
+		}
+	default:
+		return "", fmt.Errorf("unknown structured format '%s'", format)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,210 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/zcyc/idinfo/internal/parsers"
+	"github.com/zcyc/idinfo/internal/types"
+	"gopkg.in/yaml.v3"
+)
+
+// structuredSamples covers nested objects, lists, nulls, numbers and extra keys that need quoting
+func structuredSamples(t *testing.T) []*types.IDInfo {
+	t.Helper()
+	var results []*types.IDInfo
+	for _, id := range []string{
+		"550e8400-e29b-41d4-a716-446655440000",
+		"507f1f77bcf86cd799439011",
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"1700000000",
+	} {
+		parsed := parsers.ParseID(id, "")
+		if len(parsed) == 0 {
+			t.Fatalf("Failed to parse %s", id)
+		}
+		results = append(results, parsed...)
+	}
+	return results
+}
+
+// normalize passes a decoded document through JSON so numbers compare equal whichever decoder
+// read them, and drops nulls, which TOML cannot write
+func normalize(t *testing.T, v any, dropNulls bool) any {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to re-encode: %v", err)
+	}
+	var out any
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	if dropNulls {
+		out = withoutNulls(out)
+	}
+	return out
+}
+
+func withoutNulls(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if value == nil {
+				delete(v, key)
+			} else {
+				v[key] = withoutNulls(value)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = withoutNulls(v[i])
+		}
+	}
+	return v
+}
+
+func structuredJSON(t *testing.T, results []*types.IDInfo, dropNulls bool) any {
+	t.Helper()
+	text, err := structuredText("json", results, true)
+	if err != nil {
+		t.Fatalf("JSON output failed: %v", err)
+	}
+	var doc any
+	if err := json.Unmarshal([]byte(text), &doc); err != nil {
+		t.Fatalf("JSON output does not parse: %v", err)
+	}
+	return normalize(t, doc, dropNulls)
+}
+
+func TestStructured_YAMLRoundTrip(t *testing.T) {
+	results := structuredSamples(t)
+	text, err := structuredText("yaml", results, true)
+	if err != nil {
+		t.Fatalf("YAML output failed: %v", err)
+	}
+	var doc any
+	if err := yaml.Unmarshal([]byte(text), &doc); err != nil {
+		t.Fatalf("YAML output does not parse: %v\n%s", err, text)
+	}
+	if got, want := normalize(t, doc, false), structuredJSON(t, results, false); !reflect.DeepEqual(got, want) {
+		t.Errorf("YAML does not read back as the JSON output:\n%v\n%v", got, want)
+	}
+}
+
+func TestStructured_TOMLRoundTrip(t *testing.T) {
+	results := structuredSamples(t)
+	text, err := structuredText("toml", results, true)
+	if err != nil {
+		t.Fatalf("TOML output failed: %v", err)
+	}
+	var doc map[string]any
+	if _, err := toml.Decode(text, &doc); err != nil {
+		t.Fatalf("TOML output does not parse: %v\n%s", err, text)
+	}
+	if got, want := normalize(t, doc["results"], true), structuredJSON(t, results, true); !reflect.DeepEqual(got, want) {
+		t.Errorf("TOML does not read back as the JSON output:\n%v\n%v", got, want)
+	}
+}
+
+func TestStructured_Logfmt(t *testing.T) {
+	results := structuredSamples(t)
+	text, err := structuredText("logfmt", results, true)
+	if err != nil {
+		t.Fatalf("logfmt output failed: %v", err)
+	}
+	rows, err := structuredRows(results)
+	if err != nil {
+		t.Fatalf("structuredRows failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) != len(rows) {
+		t.Fatalf("Expected %d lines, got %d", len(rows), len(lines))
+	}
+	for i, line := range lines {
+		pairs := parseLogfmt(t, line)
+		for _, f := range rows[i] {
+			if f.value == nil {
+				continue
+			}
+			if got, want := pairs[f.key], scalarText(f.value); got != want {
+				t.Errorf("Line %d, %s: got %q, want %q", i+1, f.key, got, want)
+			}
+		}
+	}
+}
+
+// parseLogfmt splits a line into key=value pairs, unquoting quoted values
+func parseLogfmt(t *testing.T, line string) map[string]string {
+	t.Helper()
+	pairs := map[string]string{}
+	for line != "" {
+		key, rest, ok := strings.Cut(line, "=")
+		if !ok {
+			t.Fatalf("Missing '=' in %q", line)
+		}
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			quoted, err := strconv.QuotedPrefix(rest)
+			if err != nil {
+				t.Fatalf("Bad quoted value in %q: %v", rest, err)
+			}
+			if value, err = strconv.Unquote(quoted); err != nil {
+				t.Fatalf("Bad quoted value %s: %v", quoted, err)
+			}
+			rest = rest[len(quoted):]
+		} else {
+			value, rest, _ = strings.Cut(rest, " ")
+			rest = " " + rest
+		}
+		pairs[key] = value
+		line = strings.TrimPrefix(rest, " ")
+	}
+	return pairs
+}
+
+func TestStructured_TableHeader(t *testing.T) {
+	// The IDInfo columns come first in a fixed order, then the sorted extra keys of all rows
+	results := structuredSamples(t)
+	text, err := structuredText("csv", results, true)
+	if err != nil {
+		t.Fatalf("CSV output failed: %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(text)).ReadAll()
+	if err != nil || len(records) != len(results)+1 {
+		t.Fatalf("Expected a header and %d rows, got %d (%v)", len(results), len(records), err)
+	}
+	header := records[0]
+	fixed := typeColumns(reflect.TypeOf(types.IDInfo{}), "")
+	if !reflect.DeepEqual(header[:len(fixed)], fixed) {
+		t.Errorf("Expected the IDInfo columns first, got %v", header[:len(fixed)])
+	}
+	extra := header[len(fixed):]
+	if !sort.StringsAreSorted(extra) {
+		t.Errorf("Expected the extra columns sorted, got %v", extra)
+	}
+
+	// Every extra key of every result has its own column holding its value
+	for i, info := range results {
+		for key, value := range info.Extra {
+			column := slices.Index(header, "extra."+key)
+			if column < 0 || records[i+1][column] != value {
+				t.Errorf("Row %d: expected extra.%s = %q in its own column", i+1, key, value)
+			}
+		}
+	}
+
+	// Reordering the results does not change the header
+	slices.Reverse(results)
+	reversed, _ := structuredText("csv", results, true)
+	if strings.SplitN(reversed, "\n", 2)[0] != strings.SplitN(text, "\n", 2)[0] {
+		t.Error("Expected the same header for the same results in another order")
+	}
+}
@@ -0,0 +1,198 @@
+package output
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+
+	"github.com/zcyc/idinfo/internal/parsers"
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// templateFuncs are the helpers available to -o template=... and --template-file
+var templateFuncs = template.FuncMap{
+	"rfc3339":    func(v any) string { return templateTime(v, time.RFC3339) },
+	"rfc3339ms":  func(v any) string { return templateTime(v, "2006-01-02T15:04:05.000Z07:00") },
+	"formatTime": func(layout string, v any) string { return templateTime(v, layout) },
+	"unix":       func(v any) int64 { return templateUnix(v, time.Second) },
+	"unixMilli":  func(v any) int64 { return templateUnix(v, time.Millisecond) },
+	"age": func(v any) string {
+		if t, ok := templateTimeValue(v); ok {
+			return parsers.FormatAge(t, time.Now())
+		}
+		return ""
+	},
+	"hex":      templateHex,
+	"hexGroup": templateHexGroup,
+	"base":     templateBase,
+	"default": func(def string, v any) string {
+		if s := templateString(v); s != "" {
+			return s
+		}
+		return def
+	},
+	"upper":   func(v any) string { return strings.ToUpper(templateString(v)) },
+	"lower":   func(v any) string { return strings.ToLower(templateString(v)) },
+	"join":    func(sep string, v []string) string { return strings.Join(v, sep) },
+	"percent": parsers.FormatPercent,
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ParseTemplate compiles a Go text/template that renders one parse result
+func ParseTemplate(text string) (*template.Template, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("the template is empty")
+	}
+	return template.New("output").Funcs(templateFuncs).Parse(text)
+}
+
+// ParseTemplateFile compiles a template kept in a file
+func ParseTemplateFile(path string) (*template.Template, error) {
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseTemplate(string(text))
+}
+
+// ShowTemplate renders a parse result through a template, ending the output with a newline
+func ShowTemplate(tmpl *template.Template, info *types.IDInfo) error {
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, info); err != nil {
+		return err
+	}
+	out := sb.String()
+	if !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	fmt.Print(out)
+	return nil
+}
+
+// templateTimeValue accepts the time.Time and *time.Time fields of a result
+func templateTimeValue(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, true
+	case *time.Time:
+		if t != nil {
+			return *t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// templateTime formats a time in the display time zone, or gives "" when the result has none
+func templateTime(v any, layout string) string {
+	if t, ok := templateTimeValue(v); ok {
+		return displayTime(t, layout)
+	}
+	return ""
+}
+
+func templateUnix(v any, unit time.Duration) int64 {
+	t, ok := templateTimeValue(v)
+	if !ok {
+		return 0
+	}
+	return t.UnixNano() / int64(unit)
+}
+
+// templateString reads the string, pointer and number fields of a result as text, with "" for nil
+func templateString(v any) string {
+	switch s := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return s
+	case *string:
+		if s != nil {
+			return *s
+		}
+		return ""
+	case *int:
+		if s != nil {
+			return strconv.Itoa(*s)
+		}
+		return ""
+	case *int64:
+		if s != nil {
+			return strconv.FormatInt(*s, 10)
+		}
+		return ""
+	case *time.Time:
+		return templateTime(s, time.RFC3339)
+	}
+	return fmt.Sprint(v)
+}
+
+// templateHex encodes bytes, or a decimal integer such as .Integer, as lowercase hex
+func templateHex(v any) (string, error) {
+	if b, ok := v.([]byte); ok {
+		return hex.EncodeToString(b), nil
+	}
+	return templateBase(16, v)
+}
+
+// templateHexGroup splits hex such as .Hex (or bytes, or a decimal integer) into space-separated groups of n digits, as the card does
+func templateHexGroup(n int, v any) (string, error) {
+	digits, ok := v.(string)
+	var err error
+	if !ok {
+		digits, err = templateHex(v)
+	}
+	if err != nil || n <= 0 {
+		return digits, err
+	}
+	var groups []string
+	for len(digits) > n {
+		groups = append(groups, digits[:n])
+		digits = digits[n:]
+	}
+	return strings.Join(append(groups, digits), " "), nil
+}
+
+// templateBase writes a number in base 2 to 62; it takes bytes (read big-endian) or decimal text
+// such as .Integer and .Sequence. Base 62 uses the 0-9A-Za-z alphabet of NUIDs and GitHub tokens.
+func templateBase(base int, v any) (string, error) {
+	if base < 2 || base > 62 {
+		return "", fmt.Errorf("base %d is out of range (2-62)", base)
+	}
+	n := new(big.Int)
+	switch b := v.(type) {
+	case []byte:
+		if b == nil {
+			return "", nil
+		}
+		n.SetBytes(b)
+	default:
+		s := templateString(v)
+		if s == "" {
+			return "", nil
+		}
+		if _, ok := n.SetString(s, 10); !ok {
+			return "", fmt.Errorf("'%s' is not a decimal integer", s)
+		}
+	}
+	text := n.Text(base)
+	if base == 62 {
+		// big.Int writes base 62 as 0-9a-zA-Z
+		text = strings.Map(func(r rune) rune {
+			if unicode.IsUpper(r) {
+				return unicode.ToLower(r)
+			}
+			return unicode.ToUpper(r)
+		}, text)
+	}
+	return text, nil
+}
@@ -0,0 +1,84 @@
+package output
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+func renderTemplate(t *testing.T, text string, info *types.IDInfo) string {
+	t.Helper()
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		t.Fatalf("ParseTemplate(%q) failed: %v", text, err)
+	}
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, info); err != nil {
+		t.Fatalf("Execute(%q) failed: %v", text, err)
+	}
+	return sb.String()
+}
+
+func TestTemplate_Helpers(t *testing.T) {
+	integer := "1700000000"
+	node := ""
+	info := &types.IDInfo{
+		Hex:     "550e8400e29b41d4a716446655440000",
+		Integer: &integer,
+		Node1:   &node,
+		Binary:  []byte{0x01, 0x00},
+	}
+
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{`{{.Hex | hexGroup 4}}`, "550e 8400 e29b 41d4 a716 4466 5544 0000"},
+		{`{{.Hex | hexGroup 5}}`, "550e8 400e2 9b41d 4a716 44665 54400 00"},
+		{`{{.Binary | hexGroup 2}}`, "01 00"},
+		{`{{.Integer | hexGroup 4}}`, "6553 f100"},
+		{`{{.Integer | base 2}}`, "1100101010100111111000100000000"},
+		{`{{.Integer | base 36}}`, "s44we8"},
+		{`{{.Integer | base 62}}`, "1r31eq"}, // 0-9A-Za-z, as NUIDs and GitHub tokens use
+		{`{{.Binary | base 62}}`, "48"},
+		{`{{.Sequence | base 16}}`, ""},
+		{`{{.DateTime | rfc3339}}`, ""},
+		{`{{.DateTime | unix}}`, "0"},
+		{`{{.Node1 | default "-"}}`, "-"},
+		{`{{.Node2 | default "-"}}`, "-"},
+		{`{{.Integer | default "-"}}`, "1700000000"},
+	}
+	for _, tt := range tests {
+		if got := renderTemplate(t, tt.text, info); got != tt.expected {
+			t.Errorf("%s = %q, expected %q", tt.text, got, tt.expected)
+		}
+	}
+}
+
+func TestTemplate_Time(t *testing.T) {
+	SetTimeZone(time.UTC)
+	ts := time.Date(2023, 11, 14, 22, 13, 20, 500000000, time.UTC)
+	info := &types.IDInfo{DateTime: &ts}
+	if got := renderTemplate(t, `{{.DateTime | rfc3339}} {{.DateTime | rfc3339ms}} {{.DateTime | unixMilli}}`, info); got != "2023-11-14T22:13:20Z 2023-11-14T22:13:20.500Z 1700000000500" {
+		t.Errorf("Unexpected time rendering %q", got)
+	}
+}
+
+func TestTemplate_Errors(t *testing.T) {
+	for _, text := range []string{"", "  \n"} {
+		if _, err := ParseTemplate(text); err == nil {
+			t.Errorf("Expected an error for the empty template %q", text)
+		}
+	}
+
+	integer := "12"
+	tmpl, err := ParseTemplate(`{{.Integer | base 63}}`)
+	if err != nil {
+		t.Fatalf("ParseTemplate failed: %v", err)
+	}
+	if err := tmpl.Execute(&strings.Builder{}, &types.IDInfo{Integer: &integer}); err == nil {
+		t.Error("Expected an error for base 63")
+	}
+}
@@ -0,0 +1,430 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Tests fail below this p-value; with 128 bit positions one false alarm per ~8 corpora is expected
+const analysisSignificance = 0.001
+
+// analysisMinSample is the corpus size below which the statistics are flagged as unreliable
+const analysisMinSample = 100
+
+// AnalyzeCorpus parses many IDs of one format and measures how random they really are: the
+// frequency, runs and entropy of every bit of the decoded value, and a chi-square and entropy
+// estimate for every character position. Without forceFormat, the format of the first ID is used
+// for all of them.
+func (r *Registry) AnalyzeCorpus(inputs []string, forceFormat string) (*types.CorpusAnalysis, error) {
+	ids, infos, _, skipped := r.parseCorpus(inputs, forceFormat)
+	if len(infos) < 2 {
+		return nil, fmt.Errorf("need at least 2 parseable IDs of one format, got %d", len(infos))
+	}
+
+	analysis := &types.CorpusAnalysis{
+		Format:          infos[0].IDType,
+		Count:           len(infos),
+		Skipped:         len(skipped),
+		DeclaredEntropy: infos[0].Entropy,
+	}
+	seen := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			analysis.Duplicates++
+		}
+		seen[id] = true
+	}
+
+	analysis.Bits = bitStats(infos)
+	for _, bit := range analysis.Bits {
+		analysis.MeasuredBitEntropy += bit.Entropy
+	}
+	// A character every secret shares is part of the secret, so only its position is reported
+	analysis.Positions = positionStats(ids, infos[0], infos[0].Secret && !r.revealSecrets)
+	for _, pos := range analysis.Positions {
+		analysis.MeasuredCharEntropy += pos.Entropy
+	}
+	analysis.Findings = corpusFindings(analysis, infos)
+	return analysis, nil
+}
+
+// parseCorpus parses every non-empty line as the format of the first parseable one, or as
+// forceFormat. It returns the IDs that parsed with their details and 1-based line numbers, and
+// the line numbers that did not parse.
+func (r *Registry) parseCorpus(inputs []string, forceFormat string) ([]string, []*types.IDInfo, []int, []int) {
+	var parser types.IDParser
+	var ids []string
+	var infos []*types.IDInfo
+	var lines, skipped []int
+	for i, input := range inputs {
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
+		if parser == nil {
+			parser = r.corpusParser(input, forceFormat)
+			if parser == nil {
+				skipped = append(skipped, i+1)
+				continue
+			}
+		}
+		info, err := parser.Parse(input)
+		if err != nil {
+			skipped = append(skipped, i+1)
+			continue
+		}
+		normalizeTimes(info)
+		r.applySecretPolicy(input, []*types.IDInfo{info})
+		ids = append(ids, input)
+		infos = append(infos, info)
+		lines = append(lines, i+1)
+	}
+	return ids, infos, lines, skipped
+}
+
+// corpusParser picks the parser used for the whole corpus. Without forceFormat it is the matching
+// parser that decodes the most structure, so numeric Snowflakes are not read as Sqids: a
+// timestamp clear of the format's epoch counts most, then node or sequence components. Ties go
+// to the earlier parser, as in ParseID.
+func (r *Registry) corpusParser(input, forceFormat string) types.IDParser {
+	var best types.IDParser
+	bestScore := -1
+	for _, parser := range r.parsers {
+		if forceFormat != "" {
+			if matchesForceFormat(parser.Name(), forceFormat) {
+				if _, err := parser.Parse(input); err == nil {
+					return parser
+				}
+			}
+			continue
+		}
+		if !parser.CanParse(input) {
+			continue
+		}
+		info, err := parser.Parse(input)
+		if err != nil {
+			continue
+		}
+		if score := structureScore(info); score > bestScore {
+			best, bestScore = parser, score
+		}
+	}
+	return best
+}
+
+// structureScore rates how much of an ID's structure a parse result decodes
+func structureScore(info *types.IDInfo) int {
+	score := 0
+	// A time within a day of the epoch is what a number too small for the format decodes to
+	if info.DateTime != nil && info.DateTime.Sub(formatEpoch(info)) > 24*time.Hour {
+		score += 2
+	}
+	if info.Node1 != nil || info.Node2 != nil || info.Sequence != nil {
+		score++
+	}
+	return score
+}
+
+// bitStats measures every bit position of the decoded values, most significant bit first.
+// Only values of the most common length are compared.
+func bitStats(infos []*types.IDInfo) []types.BitStat {
+	lengths := map[int]int{}
+	for _, info := range infos {
+		lengths[len(info.Binary)]++
+	}
+	size, best := 0, 0
+	for length, count := range lengths {
+		if length > 0 && (count > best || count == best && length > size) {
+			size, best = length, count
+		}
+	}
+	if size == 0 || best < 2 {
+		return nil
+	}
+
+	var values [][]byte
+	for _, info := range infos {
+		if len(info.Binary) == size {
+			values = append(values, info.Binary)
+		}
+	}
+	stats := make([]types.BitStat, size*8)
+	sequence := make([]bool, len(values))
+	for pos := range stats {
+		ones := 0
+		for i, value := range values {
+			sequence[i] = value[pos/8]&(0x80>>(pos%8)) != 0
+			if sequence[i] {
+				ones++
+			}
+		}
+		p := float64(ones) / float64(len(values))
+		z, pValue := runsTest(sequence)
+		stats[pos] = types.BitStat{
+			Position:    pos,
+			Ones:        p,
+			Entropy:     binaryEntropy(p),
+			RunsZ:       z,
+			RunsPValue:  pValue,
+			BiasPValue:  math.Erfc(math.Abs(p-0.5) * 2 * math.Sqrt(float64(len(values))) / math.Sqrt2),
+			Fixed:       ones == 0 || ones == len(values),
+			SampleCount: len(values),
+		}
+	}
+	return stats
+}
+
+// positionStats measures each character position of IDs with the most common length. The
+// chi-square test compares against a uniform alphabet: the parser's own when it reports one,
+// otherwise every character seen at a position that varies. With hideFixed a position that never
+// varies is reported without its character.
+func positionStats(ids []string, first *types.IDInfo, hideFixed bool) []types.PositionStat {
+	lengths := map[int]int{}
+	for _, id := range ids {
+		lengths[len(id)]++
+	}
+	size, best := 0, 0
+	for length, count := range lengths {
+		if count > best || count == best && length > size {
+			size, best = length, count
+		}
+	}
+	var sample []string
+	for _, id := range ids {
+		if len(id) == size {
+			sample = append(sample, id)
+		}
+	}
+	if len(sample) < 2 {
+		return nil
+	}
+
+	counts := make([]map[byte]int, size)
+	for pos := range counts {
+		counts[pos] = map[byte]int{}
+		for _, id := range sample {
+			counts[pos][id[pos]]++
+		}
+	}
+
+	alphabetSize, _ := strconv.Atoi(first.Extra["alphabet_size"])
+	if alphabetSize < 2 {
+		alphabet := map[byte]bool{}
+		for _, c := range counts {
+			if len(c) > 1 {
+				for ch := range c {
+					alphabet[ch] = true
+				}
+			}
+		}
+		alphabetSize = len(alphabet)
+	}
+
+	stats := make([]types.PositionStat, size)
+	n := float64(len(sample))
+	for pos, c := range counts {
+		stat := types.PositionStat{Position: pos, Distinct: len(c)}
+		chars := make([]byte, 0, len(c))
+		for ch, count := range c {
+			chars = append(chars, ch)
+			p := float64(count) / n
+			stat.Entropy -= p * math.Log2(p)
+		}
+		if len(c) == 1 {
+			if !hideFixed {
+				stat.Fixed = string(chars)
+			}
+		} else if alphabetSize >= len(c) {
+			// A position that never shows some characters although a uniform one would almost
+			// surely have, like the UUID variant, is restricted by the format; test it over its own set
+			k := alphabetSize
+			if len(c) < k && math.Pow(1-1/float64(k), n) < analysisSignificance {
+				k = len(c)
+				stat.Restricted = true
+			}
+			// Characters never seen contribute their full expected count
+			expected := n / float64(k)
+			chi := float64(k-len(c)) * expected
+			for _, count := range c {
+				d := float64(count) - expected
+				chi += d * d / expected
+			}
+			stat.ChiSquare = chi
+			stat.DegreesOfFreedom = k - 1
+			stat.PValue = chiSquarePValue(chi, k-1)
+		}
+		stats[pos] = stat
+	}
+	return stats
+}
+
+// corpusFindings turns the measurements into warnings a reader can act on
+func corpusFindings(a *types.CorpusAnalysis, infos []*types.IDInfo) []string {
+	var findings []string
+	if a.Count < analysisMinSample {
+		findings = append(findings, fmt.Sprintf("only %d IDs: use at least %d for reliable statistics", a.Count, analysisMinSample))
+	}
+	if a.Duplicates > 0 {
+		findings = append(findings, fmt.Sprintf("%d duplicate IDs: the generator repeats values, e.g. it is seeded with the time or restarted with the same state", a.Duplicates))
+	}
+
+	// Per-bit entropies ignore correlation between bits, so the sum is an upper bound and
+	// falling short of the declared entropy is meaningful
+	if a.DeclaredEntropy != nil && len(a.Bits) > 0 && a.MeasuredBitEntropy < 0.9*float64(*a.DeclaredEntropy) {
+		findings = append(findings, fmt.Sprintf("measured at most %.1f random bits per ID, but the format declares %d: the generator may seed from the time or reuse node or counter values",
+			a.MeasuredBitEntropy, *a.DeclaredEntropy))
+	}
+
+	var biased, runs []int
+	for _, bit := range a.Bits {
+		if bit.Fixed {
+			continue
+		}
+		if bit.BiasPValue < analysisSignificance {
+			biased = append(biased, bit.Position)
+		}
+		if bit.RunsPValue < analysisSignificance {
+			runs = append(runs, bit.Position)
+		}
+	}
+	if len(biased) > 0 {
+		findings = append(findings, fmt.Sprintf("%d varying bits are biased towards 0 or 1 (frequency test, p < %g): %s", len(biased), analysisSignificance, formatPositions(biased)))
+	}
+	if len(runs) > 0 {
+		findings = append(findings, fmt.Sprintf("%d bits fail the runs test (p < %g), as timestamp, counter or sequentially seeded bits do: %s", len(runs), analysisSignificance, formatPositions(runs)))
+	}
+
+	var chars []int
+	for _, pos := range a.Positions {
+		if pos.DegreesOfFreedom > 0 && pos.PValue < analysisSignificance {
+			chars = append(chars, pos.Position)
+		}
+	}
+	if len(chars) > 0 {
+		findings = append(findings, fmt.Sprintf("%d character positions are not uniform (chi-square, p < %g): %s", len(chars), analysisSignificance, formatPositions(chars)))
+	}
+
+	// One node ID across a whole fleet's IDs means the fleet will collide once the sequences overlap
+	nodes := map[string]int{}
+	for _, info := range infos {
+		if info.Node1 != nil {
+			nodes[*info.Node1]++
+		}
+	}
+	if len(nodes) == 1 && a.Count >= 10 {
+		for node, count := range nodes {
+			if count == a.Count {
+				findings = append(findings, fmt.Sprintf("all IDs share node %s: expected from one host, a collision risk if they come from several", node))
+			}
+		}
+	}
+	return findings
+}
+
+// runsTest is the Wald-Wolfowitz runs test on a sequence of bits, returning the z score and the
+// two-sided p-value. Too few runs mean neighbouring values are alike, as with a clock or counter.
+func runsTest(bits []bool) (float64, float64) {
+	var ones, runs float64
+	for i, bit := range bits {
+		if bit {
+			ones++
+		}
+		if i == 0 || bit != bits[i-1] {
+			runs++
+		}
+	}
+	n := float64(len(bits))
+	zeros := n - ones
+	if ones == 0 || zeros == 0 || n < 3 {
+		return 0, 1
+	}
+	mean := 2*ones*zeros/n + 1
+	variance := (mean - 1) * (mean - 2) / (n - 1)
+	if variance <= 0 {
+		return 0, 1
+	}
+	z := (runs - mean) / math.Sqrt(variance)
+	return z, math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// binaryEntropy is the Shannon entropy of a bit that is 1 with probability p
+func binaryEntropy(p float64) float64 {
+	if p <= 0 || p >= 1 {
+		return 0
+	}
+	return -p*math.Log2(p) - (1-p)*math.Log2(1-p)
+}
+
+// chiSquarePValue is the probability of a chi-square statistic at least this large
+func chiSquarePValue(chi float64, df int) float64 {
+	return upperIncompleteGamma(float64(df)/2, chi/2)
+}
+
+// upperIncompleteGamma is the regularized upper incomplete gamma function Q(a, x), using the
+// series for x < a+1 and a continued fraction otherwise (Numerical Recipes, 6.2)
+func upperIncompleteGamma(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	lgamma, _ := math.Lgamma(a)
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1.0; n < 1000; n++ {
+			term *= x / (a + n)
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lgamma)
+	}
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1.0; i < 1000; i++ {
+		an := -i * (i - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lgamma) * h
+}
+
+// formatPositions lists positions compactly, collapsing consecutive ones into ranges
+func formatPositions(positions []int) string {
+	sort.Ints(positions)
+	var parts []string
+	for i := 0; i < len(positions); {
+		j := i
+		for j+1 < len(positions) && positions[j+1] == positions[j]+1 {
+			j++
+		}
+		if j > i {
+			parts = append(parts, fmt.Sprintf("%d-%d", positions[i], positions[j]))
+		} else {
+			parts = append(parts, strconv.Itoa(positions[i]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,137 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAnalyzeCorpus_Random(t *testing.T) {
+	var ids []string
+	for i := 0; i < 400; i++ {
+		ids = append(ids, uuid.New().String())
+	}
+	a, err := NewRegistry().AnalyzeCorpus(ids, "")
+	if err != nil {
+		t.Fatalf("AnalyzeCorpus failed: %v", err)
+	}
+	if a.Count != 400 || a.Duplicates != 0 {
+		t.Errorf("Expected 400 unique IDs, got %d with %d duplicates", a.Count, a.Duplicates)
+	}
+	if len(a.Bits) != 128 || len(a.Positions) != 36 {
+		t.Fatalf("Expected 128 bits and 36 positions, got %d and %d", len(a.Bits), len(a.Positions))
+	}
+	// The version nibble is fixed at 0100 and the variant bits at 10
+	for pos, ones := range map[int]float64{48: 0, 49: 1, 50: 0, 51: 0, 64: 1, 65: 0} {
+		if !a.Bits[pos].Fixed || a.Bits[pos].Ones != ones {
+			t.Errorf("Expected bit %d fixed at %v, got %+v", pos, ones, a.Bits[pos])
+		}
+	}
+	if a.Positions[14].Fixed != "4" || a.Positions[8].Fixed != "-" {
+		t.Errorf("Expected fixed version and hyphen positions, got %+v / %+v", a.Positions[14], a.Positions[8])
+	}
+	if !a.Positions[19].Restricted || a.Positions[19].Distinct != 4 {
+		t.Errorf("Expected the variant position to be restricted to 4 characters, got %+v", a.Positions[19])
+	}
+	if math.Abs(a.MeasuredBitEntropy-122) > 1 {
+		t.Errorf("Expected about 122 measured bits, got %.1f", a.MeasuredBitEntropy)
+	}
+	for _, finding := range a.Findings {
+		if strings.Contains(finding, "duplicate") || strings.Contains(finding, "declares") {
+			t.Errorf("Unexpected finding for random UUIDs: %s", finding)
+		}
+	}
+}
+
+func TestAnalyzeCorpus_WeakGenerator(t *testing.T) {
+	// A generator that only randomizes a 12-bit counter repeats itself and falls far short of 122 bits
+	var ids []string
+	for i := 0; i < 300; i++ {
+		ids = append(ids, fmt.Sprintf("6ba7b810-9dad-41d1-80b4-00c04fd43%03x", (i*7)%256))
+	}
+	a, err := NewRegistry().AnalyzeCorpus(ids, "uuid")
+	if err != nil {
+		t.Fatalf("AnalyzeCorpus failed: %v", err)
+	}
+	if a.Duplicates != 300-256 {
+		t.Errorf("Expected %d duplicates, got %d", 300-256, a.Duplicates)
+	}
+	var duplicates, shortfall bool
+	for _, finding := range a.Findings {
+		duplicates = duplicates || strings.Contains(finding, "duplicate IDs")
+		shortfall = shortfall || strings.Contains(finding, "declares 122")
+	}
+	if !duplicates || !shortfall {
+		t.Errorf("Expected duplicate and entropy findings, got %v", a.Findings)
+	}
+}
+
+func TestAnalyzeCorpus_Errors(t *testing.T) {
+	if _, err := NewRegistry().AnalyzeCorpus([]string{uuid.New().String()}, ""); err == nil {
+		t.Error("Expected an error for a single ID")
+	}
+	a, err := NewRegistry().AnalyzeCorpus([]string{uuid.New().String(), "not an id!", uuid.New().String(), ""}, "uuid")
+	if err != nil {
+		t.Fatalf("AnalyzeCorpus failed: %v", err)
+	}
+	if a.Count != 2 || a.Skipped != 1 {
+		t.Errorf("Expected 2 IDs and 1 skipped line, got %d and %d", a.Count, a.Skipped)
+	}
+}
+
+func TestAnalysisStatistics(t *testing.T) {
+	// Chi-square critical values at p = 0.05
+	for df, chi := range map[int]float64{1: 3.841, 10: 18.307, 31: 44.985} {
+		if p := chiSquarePValue(chi, df); math.Abs(p-0.05) > 0.0005 {
+			t.Errorf("chiSquarePValue(%v, %d) = %v, expected 0.05", chi, df, p)
+		}
+	}
+
+	// A clock-like bit that flips once has far too few runs; an alternating one far too many
+	clock := make([]bool, 100)
+	alternating := make([]bool, 100)
+	for i := range clock {
+		clock[i] = i >= 50
+		alternating[i] = i%2 == 0
+	}
+	if z, p := runsTest(clock); z >= 0 || p > 0.001 {
+		t.Errorf("Expected too few runs, got z=%v p=%v", z, p)
+	}
+	if z, p := runsTest(alternating); z <= 0 || p > 0.001 {
+		t.Errorf("Expected too many runs, got z=%v p=%v", z, p)
+	}
+
+	if got := formatPositions([]int{9, 1, 2, 3, 7}); got != "1-3, 7, 9" {
+		t.Errorf("Unexpected position list %q", got)
+	}
+}
+
+func TestAnalyzeCorpus_Secrets(t *testing.T) {
+	// API keys sharing most of their body: the shared characters must not be printed
+	const alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	var ids []string
+	for i := 0; i < 50; i++ {
+		ids = append(ids, fmt.Sprintf("sk_live_4eC39HqLyjWDarjt%c%c%c%c", alphabet[i%62], alphabet[(i*7)%62], alphabet[(i*13)%62], alphabet[(i*31)%62]))
+	}
+	registry := NewRegistry()
+	a, err := registry.AnalyzeCorpus(ids, "")
+	if err != nil {
+		t.Fatalf("AnalyzeCorpus failed: %v", err)
+	}
+	for _, pos := range a.Positions {
+		if pos.Fixed != "" {
+			t.Fatalf("Expected fixed characters of secrets to be hidden, got %q at %d", pos.Fixed, pos.Position)
+		}
+	}
+	if a.Positions[10].Distinct != 1 {
+		t.Errorf("Expected position 10 to be reported as fixed, got %+v", a.Positions[10])
+	}
+
+	registry.SetRevealSecrets(true)
+	if a, err = registry.AnalyzeCorpus(ids, ""); err != nil || a.Positions[10].Fixed != "C" {
+		t.Errorf("Expected the fixed character with --reveal-secrets, got %+v (%v)", a.Positions[10], err)
+	}
+}
@@ -0,0 +1,234 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// awsResourcePrefix describes the service and resource type behind an AWS ID prefix
+type awsResourcePrefix struct {
+	service      string
+	resourceType string
+}
+
+// Prefixes of AWS resource IDs that use the 8- or 17-character hex suffix
+var awsResourcePrefixes = map[string]awsResourcePrefix{
+	"i":          {"ec2", "instance"},
+	"r":          {"ec2", "reservation"},
+	"ami":        {"ec2", "image"},
+	"vol":        {"ebs", "volume"},
+	"snap":       {"ebs", "snapshot"},
+	"sg":         {"ec2", "security-group"},
+	"sgr":        {"ec2", "security-group-rule"},
+	"vpc":        {"ec2", "vpc"},
+	"subnet":     {"ec2", "subnet"},
+	"eni":        {"ec2", "network-interface"},
+	"igw":        {"ec2", "internet-gateway"},
+	"eigw":       {"ec2", "egress-only-internet-gateway"},
+	"nat":        {"ec2", "nat-gateway"},
+	"rtb":        {"ec2", "route-table"},
+	"acl":        {"ec2", "network-acl"},
+	"eipalloc":   {"ec2", "elastic-ip-allocation"},
+	"eipassoc":   {"ec2", "elastic-ip-association"},
+	"pcx":        {"ec2", "vpc-peering-connection"},
+	"vpce":       {"ec2", "vpc-endpoint"},
+	"tgw":        {"ec2", "transit-gateway"},
+	"tgw-attach": {"ec2", "transit-gateway-attachment"},
+	"dopt":       {"ec2", "dhcp-options"},
+	"lt":         {"ec2", "launch-template"},
+	"key":        {"ec2", "key-pair"},
+	"fs":         {"efs", "file-system"},
+	"fsmt":       {"efs", "mount-target"},
+	"vgw":        {"ec2", "vpn-gateway"},
+	"cgw":        {"ec2", "customer-gateway"},
+	"vpn":        {"ec2", "vpn-connection"},
+}
+
+var (
+	awsResourceIDRegex = regexp.MustCompile(`^([a-z]+(?:-[a-z]+)?)-([0-9a-f]+)$`)
+	awsRegionRegex     = regexp.MustCompile(`^[a-z]{2}(-gov|-iso[a-z]?)?-[a-z]+-\d$`)
+	awsAccountRegex    = regexp.MustCompile(`^\d{12}$`)
+)
+
+// Known ARN partitions
+var awsPartitions = map[string]string{
+	"aws":        "AWS commercial",
+	"aws-cn":     "AWS China",
+	"aws-us-gov": "AWS GovCloud (US)",
+	"aws-iso":    "AWS ISO",
+	"aws-iso-b":  "AWS ISOB",
+}
+
+// AWSResourceIDParser handles prefixed AWS resource IDs such as i-0abc... or vol-...
+type AWSResourceIDParser struct{}
+
+func (p *AWSResourceIDParser) Name() string {
+	return "AWSResourceID"
+}
+
+func (p *AWSResourceIDParser) CanParse(input string) bool {
+	m := awsResourceIDRegex.FindStringSubmatch(input)
+	if m == nil {
+		return false
+	}
+	if _, known := awsResourcePrefixes[m[1]]; !known {
+		return false
+	}
+	// Older IDs have 8 hex characters, IDs issued since 2016 have 17
+	return len(m[2]) == 8 || len(m[2]) == 17
+}
+
+func (p *AWSResourceIDParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid AWS resource ID format")
+	}
+
+	m := awsResourceIDRegex.FindStringSubmatch(input)
+	prefix, suffix := m[1], m[2]
+	resource := awsResourcePrefixes[prefix]
+
+	style := "short (8 hex characters, pre-2016)"
+	if len(suffix) == 17 {
+		style = "long (17 hex characters)"
+	}
+
+	// The 17-character suffix is odd-length, so pad the leading nibble for bytes
+	padded := suffix
+	if len(padded)%2 != 0 {
+		padded = "0" + padded
+	}
+	raw, err := hex.DecodeString(padded)
+	if err != nil {
+		return nil, err
+	}
+
+	entropy := len(suffix) * 4
+
+	return &types.IDInfo{
+		IDType:   fmt.Sprintf("AWS %s %s ID", strings.ToUpper(resource.service), resource.resourceType),
+		Standard: input,
+		Size:     len(suffix) * 4,
+		Entropy:  &entropy,
+		Hex:      suffix,
+		Binary:   raw,
+		Extra: map[string]string{
+			"provider":      "AWS",
+			"service":       resource.service,
+			"resource_type": resource.resourceType,
+			"prefix":        prefix,
+			"id_style":      style,
+			"region":        "not encoded (IDs are region-scoped)",
+		},
+	}, nil
+}
+
+func (p *AWSResourceIDParser) Generate() (string, error) {
+	raw := make([]byte, 9)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	return "i-" + hex.EncodeToString(raw)[1:], nil
+}
+
+// AWSARNParser handles Amazon Resource Names
+type AWSARNParser struct{}
+
+func (p *AWSARNParser) Name() string {
+	return "AWSARN"
+}
+
+func (p *AWSARNParser) CanParse(input string) bool {
+	parts := strings.SplitN(input, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" || parts[2] == "" || parts[5] == "" {
+		return false
+	}
+	if _, known := awsPartitions[parts[1]]; !known {
+		return false
+	}
+	if parts[3] != "" && !awsRegionRegex.MatchString(parts[3]) {
+		return false
+	}
+	return parts[4] == "" || parts[4] == "aws" || awsAccountRegex.MatchString(parts[4])
+}
+
+func (p *AWSARNParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid ARN format")
+	}
+
+	parts := strings.SplitN(input, ":", 6)
+	partition, service, region, account, resource := parts[1], parts[2], parts[3], parts[4], parts[5]
+
+	extra := map[string]string{
+		"provider":  "AWS",
+		"partition": fmt.Sprintf("%s (%s)", partition, awsPartitions[partition]),
+		"service":   service,
+		"resource":  resource,
+	}
+
+	// Resources are "id", "type/id" or "type:id", except S3 buckets and objects ("bucket" or
+	// "bucket/key"), which have no type segment; access points and the like carry an account
+	resourceType, resourceID := "", resource
+	if service == "s3" && account == "" {
+		bucket, key, isObject := strings.Cut(resource, "/")
+		extra["bucket"] = bucket
+		resourceType = "bucket"
+		if isObject {
+			extra["key"] = key
+			resourceType = "object"
+		}
+	} else if i := strings.IndexAny(resource, "/:"); i > 0 {
+		resourceType, resourceID = resource[:i], resource[i+1:]
+	}
+	extra["resource_id"] = resourceID
+	if resourceType != "" {
+		extra["resource_type"] = resourceType
+	}
+	if region != "" {
+		extra["region"] = region
+	} else {
+		extra["region"] = "global"
+	}
+	if account != "" {
+		extra["account_id"] = account
+	}
+
+	info := &types.IDInfo{
+		IDType:   fmt.Sprintf("AWS ARN (%s)", service),
+		Standard: input,
+		Size:     len(input) * 8,
+		Hex:      hex.EncodeToString([]byte(input)),
+		Binary:   []byte(input),
+		Extra:    extra,
+	}
+	if resourceType != "" {
+		info.IDType = fmt.Sprintf("AWS ARN (%s %s)", service, resourceType)
+	}
+	if account != "" {
+		info.Node1 = &account
+	}
+	regionStr := extra["region"]
+	info.Node2 = &regionStr
+
+	// Embedded resource IDs such as instance/i-0abc... are decoded as well
+	if (&AWSResourceIDParser{}).CanParse(resourceID) {
+		extra["embedded_resource_id"] = resourceID
+	}
+
+	return info, nil
+}
+
+func (p *AWSARNParser) Generate() (string, error) {
+	instanceID, err := (&AWSResourceIDParser{}).Generate()
+	if err != nil {
+		return "", err
+	}
+	return "arn:aws:ec2:us-east-1:123456789012:instance/" + instanceID, nil
+}
@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestAWSResourceIDParser(t *testing.T) {
+	parser := &AWSResourceIDParser{}
+
+	tests := []struct {
+		input        string
+		service      string
+		resourceType string
+	}{
+		{"i-0abcdef1234567890", "ec2", "instance"},
+		{"i-1a2b3c4d", "ec2", "instance"},
+		{"vol-049df61146c4d7901", "ebs", "volume"},
+		{"subnet-0bb1c79de3EXAMPLE", "", ""}, // uppercase is not valid hex
+		{"tgw-attach-0123456789abcdef0", "ec2", "transit-gateway-attachment"},
+	}
+
+	for _, test := range tests {
+		ok := parser.CanParse(test.input)
+		if test.service == "" {
+			if ok {
+				t.Errorf("Expected to reject %s", test.input)
+			}
+			continue
+		}
+		if !ok {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Extra["service"] != test.service || info.Extra["resource_type"] != test.resourceType {
+			t.Errorf("%s: expected %s/%s, got %s/%s", test.input, test.service, test.resourceType, info.Extra["service"], info.Extra["resource_type"])
+		}
+	}
+
+	invalid := []string{"", "i-0abc", "i-0abcdef12345678", "xyz-0abcdef1234567890", "i-0abcdef1234567890a"}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated ID is not valid: %s", generated)
+	}
+}
+
+func TestAWSARNParser(t *testing.T) {
+	parser := &AWSARNParser{}
+
+	info, err := parser.Parse("arn:aws:ec2:us-east-1:123456789012:instance/i-0abcdef1234567890")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	expected := map[string]string{
+		"service":              "ec2",
+		"region":               "us-east-1",
+		"account_id":           "123456789012",
+		"resource_type":        "instance",
+		"resource_id":          "i-0abcdef1234567890",
+		"embedded_resource_id": "i-0abcdef1234567890",
+	}
+	for key, value := range expected {
+		if info.Extra[key] != value {
+			t.Errorf("Expected %s=%s, got %s", key, value, info.Extra[key])
+		}
+	}
+
+	// S3 ARNs have no region or account
+	info, err = parser.Parse("arn:aws:s3:::my-bucket")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["region"] != "global" || info.Extra["resource_id"] != "my-bucket" || info.Extra["bucket"] != "my-bucket" {
+		t.Errorf("Unexpected S3 ARN details: %v", info.Extra)
+	}
+
+	// S3 objects split into bucket and key, not a resource type
+	info, err = parser.Parse("arn:aws:s3:::my-bucket/logs/2024/app.log")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["bucket"] != "my-bucket" || info.Extra["key"] != "logs/2024/app.log" || info.Extra["resource_type"] != "object" {
+		t.Errorf("Unexpected S3 object ARN details: %v", info.Extra)
+	}
+
+	// S3 access points do have a type segment
+	info, err = parser.Parse("arn:aws:s3:us-west-2:123456789012:accesspoint/reports")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["resource_type"] != "accesspoint" || info.Extra["resource_id"] != "reports" || info.Extra["bucket"] != "" {
+		t.Errorf("Unexpected S3 access point ARN details: %v", info.Extra)
+	}
+
+	// IAM role with path, GovCloud partition
+	info, err = parser.Parse("arn:aws-us-gov:iam::123456789012:role/service/app")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["resource_type"] != "role" || info.Extra["resource_id"] != "service/app" {
+		t.Errorf("Unexpected IAM ARN details: %v", info.Extra)
+	}
+
+	invalid := []string{
+		"",
+		"arn:aws:ec2",
+		"arn:foo:ec2:us-east-1:123456789012:instance/i-1",
+		"arn:aws:ec2:useast1:123456789012:instance/i-1",
+		"arn:aws:ec2:us-east-1:12345:instance/i-1",
+		"arn:aws:ec2:us-east-1:123456789012:",
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+}
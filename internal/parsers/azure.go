@@ -0,0 +1,103 @@
+package parsers
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// AzureParser handles Azure Resource Manager resource IDs
+// (/subscriptions/{id}/resourceGroups/{rg}/providers/{namespace}/{type}/{name}...)
+type AzureParser struct{}
+
+func (p *AzureParser) Name() string {
+	return "Azure"
+}
+
+func (p *AzureParser) CanParse(input string) bool {
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	if !strings.HasPrefix(input, "/") || len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return false
+	}
+	if _, err := uuid.Parse(segments[1]); err != nil {
+		return false
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *AzureParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid Azure resource ID format")
+	}
+
+	segments := strings.Split(strings.TrimPrefix(input, "/"), "/")
+	subscription := strings.ToLower(segments[1])
+
+	extra := map[string]string{
+		"provider":        "Azure",
+		"subscription_id": subscription,
+	}
+
+	resourceType := "subscription"
+	var resourceGroup, namespace string
+	var typeSegments []string
+	var name string
+
+	i := 2
+	if i+1 < len(segments) && strings.EqualFold(segments[i], "resourceGroups") {
+		resourceGroup = segments[i+1]
+		extra["resource_group"] = resourceGroup
+		resourceType = "resource group"
+		name = resourceGroup
+		i += 2
+	}
+	if i+1 < len(segments) && strings.EqualFold(segments[i], "providers") {
+		namespace = segments[i+1]
+		extra["provider_namespace"] = namespace
+		i += 2
+
+		// Remaining segments alternate type/name, nesting child resources
+		for ; i+1 < len(segments); i += 2 {
+			typeSegments = append(typeSegments, segments[i])
+			name = segments[i+1]
+		}
+		if len(typeSegments) > 0 {
+			resourceType = namespace + "/" + strings.Join(typeSegments, "/")
+		}
+	}
+	if i < len(segments) {
+		extra["unparsed_suffix"] = strings.Join(segments[i:], "/")
+	}
+
+	extra["resource_type"] = resourceType
+	if name != "" {
+		extra["resource_name"] = name
+	}
+
+	info := &types.IDInfo{
+		IDType:   fmt.Sprintf("Azure resource ID (%s)", resourceType),
+		Standard: input,
+		Size:     len(input) * 8,
+		Hex:      hex.EncodeToString([]byte(input)),
+		Binary:   []byte(input),
+		Node1:    &subscription,
+		Extra:    extra,
+	}
+	if resourceGroup != "" {
+		info.Node2 = &resourceGroup
+	}
+	return info, nil
+}
+
+func (p *AzureParser) Generate() (string, error) {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/example-rg/providers/Microsoft.Compute/virtualMachines/vm-1", uuid.New()), nil
+}
@@ -0,0 +1,53 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestAzureParser(t *testing.T) {
+	parser := &AzureParser{}
+
+	input := "/subscriptions/12345678-1234-1234-1234-123456789abc/resourceGroups/prod-rg/providers/Microsoft.Network/virtualNetworks/vnet-1/subnets/default"
+	info, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	expected := map[string]string{
+		"subscription_id":    "12345678-1234-1234-1234-123456789abc",
+		"resource_group":     "prod-rg",
+		"provider_namespace": "Microsoft.Network",
+		"resource_type":      "Microsoft.Network/virtualNetworks/subnets",
+		"resource_name":      "default",
+	}
+	for key, value := range expected {
+		if info.Extra[key] != value {
+			t.Errorf("Expected %s=%s, got %s", key, value, info.Extra[key])
+		}
+	}
+	if info.Node2 == nil || *info.Node2 != "prod-rg" {
+		t.Errorf("Expected Node2 to be the resource group, got %v", info.Node2)
+	}
+
+	info, err = parser.Parse("/subscriptions/12345678-1234-1234-1234-123456789abc")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["resource_type"] != "subscription" {
+		t.Errorf("Expected subscription, got %s", info.Extra["resource_type"])
+	}
+
+	invalid := []string{"", "subscriptions/12345678-1234-1234-1234-123456789abc", "/subscriptions/not-a-guid", "/subscriptions/12345678-1234-1234-1234-123456789abc//x"}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated ID is not valid: %s", generated)
+	}
+}
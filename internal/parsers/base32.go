@@ -4,76 +4,242 @@ import (
 	"crypto/rand"
 	"encoding/base32"
 	"fmt"
-	"regexp"
+	"math/big"
 	"strings"
 
 	"github.com/zcyc/idinfo/internal/types"
 )
 
-// Base32Parser handles parsing of Base32 encoded IDs
-type Base32Parser struct{}
+// base32Variant describes one member of the Base32 family
+type base32Variant struct {
+	name     string
+	label    string
+	alphabet string
+	padded   bool // RFC 4648 alphabets pad to a multiple of 8 characters with '='
+	lower    bool // Canonical form is lowercase
+}
+
+// Base32 alphabets in the order they are tried; Geohash only decodes under its own parser
+var base32Variants = []base32Variant{
+	{"std", "RFC 4648 Base32", "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567", true, false},
+	{"hex", "RFC 4648 base32hex (extended hex)", "0123456789ABCDEFGHIJKLMNOPQRSTUV", true, false},
+	{"zbase32", "z-base-32", "ybndrfg8ejkmcpqxot1uwisza345h769", false, true},
+	{"geohash", "Geohash", "0123456789bcdefghjkmnpqrstuvwxyz", false, true},
+}
+
+// base32VariantAliases maps generation and parsing option names to variant names
+var base32VariantAliases = map[string]string{
+	"":          "std",
+	"std":       "std",
+	"rfc4648":   "std",
+	"hex":       "hex",
+	"base32hex": "hex",
+	"zbase32":   "zbase32",
+	"z-base-32": "zbase32",
+	"zbase":     "zbase32",
+	"geohash":   "geohash",
+}
+
+// lookupBase32Variant resolves a variant name or alias
+func lookupBase32Variant(name string) (base32Variant, bool) {
+	canonical, ok := base32VariantAliases[strings.ToLower(name)]
+	if !ok {
+		return base32Variant{}, false
+	}
+	for _, v := range base32Variants {
+		if v.name == canonical {
+			return v, true
+		}
+	}
+	return base32Variant{}, false
+}
+
+// normalize folds case to the variant's canonical form; lowercase alphabets are returned as is
+func (v base32Variant) normalize(input string) string {
+	if v.lower {
+		return input
+	}
+	return strings.ToUpper(input)
+}
+
+// decode strips padding and decodes the input, reporting how its padding compares to the canonical
+// form and whether the unused trailing bits are zero
+func (v base32Variant) decode(input string) ([]byte, string, bool, error) {
+	s := v.normalize(input)
+	body := strings.TrimRight(s, "=")
+	given := len(s) - len(body)
+	if body == "" || strings.Contains(body, "=") {
+		return nil, "", false, fmt.Errorf("invalid %s padding", v.label)
+	}
+	if given > 0 && !v.padded {
+		return nil, "", false, fmt.Errorf("%s does not use padding", v.label)
+	}
+	if v.lower && input != strings.ToLower(input) {
+		return nil, "", false, fmt.Errorf("%s is lowercase", v.label)
+	}
+	// A final group of 1, 3 or 6 characters cannot hold a whole number of bytes
+	if rem := len(body) % 8; rem == 1 || rem == 3 || rem == 6 {
+		return nil, "", false, fmt.Errorf("invalid %s length %d", v.label, len(body))
+	}
+
+	enc := base32.NewEncoding(v.alphabet).WithPadding(base32.NoPadding)
+	decoded, err := enc.DecodeString(body)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("not valid %s: %v", v.label, err)
+	}
+	// Re-encoding only round-trips when the unused trailing bits are zero
+	canonicalBits := enc.EncodeToString(decoded) == body
+
+	padding := "none (unpadded alphabet)"
+	if v.padded {
+		expected := (8 - len(body)%8) % 8
+		switch {
+		case given == expected && expected == 0:
+			padding = "canonical (none needed)"
+		case given == expected:
+			padding = fmt.Sprintf("canonical (%d characters)", given)
+		case given == 0:
+			padding = fmt.Sprintf("omitted (%d characters expected)", expected)
+		default:
+			padding = fmt.Sprintf("non-canonical (%d characters, %d expected)", given, expected)
+		}
+	}
+	return decoded, padding, canonicalBits, nil
+}
+
+// Base32Parser handles the Base32 family: RFC 4648 Base32, base32hex, z-base-32 and Geohash.
+// The zero value tries each alphabet in turn; a parser with a variant set only decodes that alphabet.
+type Base32Parser struct {
+	variant string
+}
 
 func (p *Base32Parser) Name() string {
+	switch p.variant {
+	case "geohash":
+		return "Geohash"
+	case "hex":
+		return "Base32Hex"
+	case "zbase32":
+		return "ZBase32"
+	}
 	return "Base32"
 }
 
 func (p *Base32Parser) CanParse(input string) bool {
-	// Base32 IDs are typically 8-64 characters long and may have padding
-	if len(input) < 8 || len(input) > 64 {
+	switch p.variant {
+	case "geohash":
+		return canParseGeohash(input) && !isTimeNumberForm(input)
+	case "hex", "zbase32":
+		// The zero value already reports these alphabets when auto-detecting; the variant
+		// parsers are there for -f base32hex and -f zbase32
 		return false
 	}
 
-	// Check if all characters are valid Base32
-	validChars := regexp.MustCompile(`^[A-Z2-7]+=*$`)
-	if !validChars.MatchString(strings.ToUpper(input)) {
+	// Base32 IDs are typically 8-64 characters long and may have padding
+	if len(input) < 8 || len(input) > 64 {
 		return false
 	}
 
-	// Length should be multiple of 8 (with padding) or follow Base32 rules
-	upperInput := strings.ToUpper(input)
-
-	// Try to decode to validate
-	_, err := base32.StdEncoding.DecodeString(upperInput)
-	if err != nil {
-		// Try without padding
-		_, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(upperInput, "="))
+	for _, v := range p.candidates() {
+		if v.name == "geohash" {
+			continue
+		}
+		_, _, strict, err := v.decode(input)
 		if err != nil {
-			return false
+			continue
+		}
+		switch v.name {
+		case "std":
+			return true
+		case "hex":
+			// Decimal numbers and hex hashes are valid base32hex, so require a letter beyond F
+			// and canonical trailing bits before claiming the input
+			if strict && strings.ContainsAny(strings.ToUpper(input), "GHIJKLMNOPQRSTUV") {
+				return true
+			}
+		case "zbase32":
+			// Only reached when RFC 4648 could not decode the input
+			if strict {
+				return true
+			}
 		}
 	}
-
-	return true
+	return false
 }
 
 func (p *Base32Parser) Parse(input string) (*types.IDInfo, error) {
-	input = strings.TrimSpace(strings.ToUpper(input))
-
-	if !p.CanParse(input) {
+	input = strings.TrimSpace(input)
+	if input == "" {
 		return nil, fmt.Errorf("invalid Base32 format")
 	}
+	if p.variant == "geohash" {
+		return parseGeohash(input)
+	}
 
-	// Try to decode
-	decoded, err := base32.StdEncoding.DecodeString(input)
-	if err != nil {
-		// Try without padding
-		decoded, err = base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.TrimRight(input, "="))
+	var matched []base32Variant
+	var firstErr error
+	var decoded []byte
+	var padding string
+	var strict bool
+	for _, v := range p.candidates() {
+		if p.variant == "" && v.name == "geohash" {
+			// Geohash is normally reported as an alternative; it has its own parser for auto-detection
+			if canParseGeohash(input) {
+				matched = append(matched, v)
+			}
+			continue
+		}
+		d, pad, s, err := v.decode(input)
 		if err != nil {
-			return nil, fmt.Errorf("failed to decode Base32: %v", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
 		}
+		if len(matched) == 0 {
+			decoded, padding, strict = d, pad, s
+		}
+		matched = append(matched, v)
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("failed to decode Base32: %v", firstErr)
 	}
 
-	// Calculate entropy
-	entropy := int(float64(len(strings.TrimRight(input, "="))) * 5.0) // log2(32) = 5
+	variant := matched[0]
+	if variant.name == "geohash" {
+		return parseGeohash(input)
+	}
+
+	normalized := variant.normalize(input)
+	entropy := len(strings.TrimRight(normalized, "=")) * 5 // log2(32) = 5
 
 	extra := map[string]string{
-		"alphabet":     "Base32 (A-Z, 2-7)",
+		"alphabet":     fmt.Sprintf("%s (%s)", variant.label, variant.alphabet),
 		"decoded_size": fmt.Sprintf("%d bytes", len(decoded)),
-		"padding":      fmt.Sprintf("%d characters", strings.Count(input, "=")),
-		"encoding":     "RFC 4648 Base32",
+		"padding":      padding,
+		"encoding":     variant.label,
+	}
+	if !strict {
+		extra["trailing_bits"] = "non-zero (not canonical)"
+	}
+	if len(matched) > 1 {
+		var others []string
+		for _, v := range matched[1:] {
+			others = append(others, v.label)
+		}
+		extra["also_valid_as"] = strings.Join(others, ", ")
+	}
+	switch variant.name {
+	case "hex":
+		extra["used_by"] = "DNSSEC NSEC3 hashes, Xid"
+	case "zbase32":
+		extra["used_by"] = "Tor, Mainline DHT, ZRTP"
 	}
 
 	// Try to detect possible content types based on decoded size
 	switch len(decoded) {
+	case 12:
+		extra["possible_type"] = "96-bit identifier (ObjectId/Xid size)"
 	case 16:
 		extra["possible_type"] = "128-bit identifier (UUID size)"
 	case 20:
@@ -92,7 +258,8 @@ func (p *Base32Parser) Parse(input string) (*types.IDInfo, error) {
 
 	return &types.IDInfo{
 		IDType:   "Base32",
-		Standard: input,
+		Version:  variant.label,
+		Standard: normalized,
 		Size:     len(decoded) * 8,
 		Entropy:  &entropy,
 		Hex:      fmt.Sprintf("%x", decoded),
@@ -101,15 +268,51 @@ func (p *Base32Parser) Parse(input string) (*types.IDInfo, error) {
 	}, nil
 }
 
+// candidates returns the variants this parser tries, in order
+func (p *Base32Parser) candidates() []base32Variant {
+	if p.variant == "" {
+		return base32Variants
+	}
+	if v, ok := lookupBase32Variant(p.variant); ok {
+		return []base32Variant{v}
+	}
+	return nil
+}
+
 func (p *Base32Parser) Generate() (string, error) {
+	return GenerateBase32(p.variant)
+}
+
+// GenerateBase32 generates a random value in the given Base32 variant: std (default), hex,
+// zbase32 or geohash. Byte-oriented variants encode 20 random bytes; Geohash picks a random
+// 9-character cell (roughly 5 m x 5 m).
+func GenerateBase32(variant string) (string, error) {
+	v, ok := lookupBase32Variant(variant)
+	if !ok {
+		return "", fmt.Errorf("unknown Base32 variant '%s' (use std, hex, zbase32 or geohash)", variant)
+	}
+
+	if v.name == "geohash" {
+		var sb strings.Builder
+		for i := 0; i < 9; i++ {
+			n, err := rand.Int(rand.Reader, big.NewInt(32))
+			if err != nil {
+				return "", fmt.Errorf("failed to generate random Geohash: %v", err)
+			}
+			sb.WriteByte(v.alphabet[n.Int64()])
+		}
+		return sb.String(), nil
+	}
+
 	// Generate 20 random bytes (common for identifiers)
 	randomBytes := make([]byte, 20)
-	_, err := rand.Read(randomBytes)
-	if err != nil {
+	if _, err := rand.Read(randomBytes); err != nil {
 		return "", fmt.Errorf("failed to generate random bytes: %v", err)
 	}
 
-	// Encode as Base32
-	encoded := base32.StdEncoding.EncodeToString(randomBytes)
-	return encoded, nil
+	enc := base32.NewEncoding(v.alphabet)
+	if !v.padded {
+		enc = enc.WithPadding(base32.NoPadding)
+	}
+	return enc.EncodeToString(randomBytes), nil
 }
@@ -54,3 +54,120 @@ func TestBase32Parser(t *testing.T) {
 		t.Errorf("Generated Base32 ID is not valid: %s", generated)
 	}
 }
+
+func TestBase32Parser_Variants(t *testing.T) {
+	parser := &Base32Parser{}
+
+	tests := []struct {
+		input   string
+		version string
+		hex     string
+		padding string
+	}{
+		{"MZXW6YTBOI======", "RFC 4648 Base32", "666f6f626172", "canonical (6 characters)"},
+		{"mzxw6ytboi", "RFC 4648 Base32", "666f6f626172", "omitted (6 characters expected)"},
+		{"MZXW6YTBOI==", "RFC 4648 Base32", "666f6f626172", "non-canonical (2 characters, 6 expected)"},
+		{"CPNMUOJ1E8======", "RFC 4648 base32hex (extended hex)", "666f6f626172", "canonical (6 characters)"},
+		{"9m4e2mr0ui7e8ibp9lsg", "RFC 4648 base32hex (extended hex)", "4d88e15b60f48ee449794d79", "omitted (4 characters expected)"},
+		{"pb1sa5dxrb5s6hucco", "z-base-32", "68656c6c6f20776f726c64", "none (unpadded alphabet)"},
+	}
+
+	for _, tt := range tests {
+		info, err := parser.Parse(tt.input)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", tt.input, err)
+			continue
+		}
+		if info.Version != tt.version {
+			t.Errorf("%s: expected variant %s, got %s", tt.input, tt.version, info.Version)
+		}
+		if info.Hex != tt.hex {
+			t.Errorf("%s: expected %s, got %s", tt.input, tt.hex, info.Hex)
+		}
+		if info.Extra["padding"] != tt.padding {
+			t.Errorf("%s: expected padding %q, got %q", tt.input, tt.padding, info.Extra["padding"])
+		}
+	}
+
+	// Xid's base32hex strings are detected once they contain a letter beyond F
+	if !parser.CanParse("9m4e2mr0ui7e8ibp9lsg") {
+		t.Error("Expected to detect base32hex Xid string")
+	}
+	// Decimal numbers and hex hashes are not claimed as base32hex
+	for _, input := range []string{"1234567890", "d41d8cd98f00b204e9800998ecf8427e"} {
+		if parser.CanParse(input) {
+			t.Errorf("Expected not to claim %s as Base32", input)
+		}
+	}
+	// Lowercase strings that are also valid RFC 4648 prefer the standard alphabet
+	info, err := parser.Parse("c3zs6aubqe")
+	if err != nil || info.Version != "RFC 4648 Base32" || info.Extra["also_valid_as"] != "z-base-32" {
+		t.Errorf("Expected RFC 4648 with z-base-32 alternative, got %v", info)
+	}
+
+	// A final group of 3 characters cannot be decoded
+	if _, err := parser.Parse("MZXW6YTBOIA"); err == nil {
+		t.Error("Expected error for an impossible Base32 length")
+	}
+}
+
+func TestGenerateBase32(t *testing.T) {
+	for _, variant := range []string{"std", "hex", "zbase32", "geohash"} {
+		generated, err := GenerateBase32(variant)
+		if err != nil {
+			t.Errorf("Failed to generate %s: %v", variant, err)
+			continue
+		}
+		parser := &Base32Parser{variant: variant}
+		info, err := parser.Parse(generated)
+		if err != nil {
+			t.Errorf("Generated %s value %s does not parse: %v", variant, generated, err)
+			continue
+		}
+		if variant == "geohash" && info.IDType != "Geohash" {
+			t.Errorf("Expected Geohash, got %s", info.IDType)
+		}
+	}
+
+	if _, err := GenerateBase32("base64"); err == nil {
+		t.Error("Expected error for an unknown variant")
+	}
+}
+
+func TestBase32Parser_ForceVariant(t *testing.T) {
+	tests := []struct {
+		force   string
+		input   string
+		version string
+	}{
+		{"base32", "mzxw6ytb", "RFC 4648 Base32"},
+		{"zbase32", "mzxw6ytb", "z-base-32"},
+		{"z-base-32", "pb1sa5dxrb5s6hucco", "z-base-32"},
+		{"base32hex", "CPNMUOJ1E8", "RFC 4648 base32hex (extended hex)"},
+	}
+	for _, tt := range tests {
+		results := ParseID(tt.input, tt.force)
+		if len(results) != 1 {
+			t.Errorf("-f %s %s: expected one result, got %d", tt.force, tt.input, len(results))
+			continue
+		}
+		if results[0].Version != tt.version {
+			t.Errorf("-f %s %s: expected %s, got %s", tt.force, tt.input, tt.version, results[0].Version)
+		}
+	}
+
+	// mzxw6ytb uses letters outside the base32hex alphabet
+	if results := ParseID("mzxw6ytb", "base32hex"); len(results) != 0 {
+		t.Errorf("Expected -f base32hex to reject mzxw6ytb, got %s", results[0].Version)
+	}
+	// The variant parsers do not add duplicate results when auto-detecting
+	count := 0
+	for _, info := range ParseID("pb1sa5dxrb5s6hucco", "") {
+		if info.IDType == "Base32" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("Expected one Base32 result when auto-detecting, got %d", count)
+	}
+}
@@ -68,16 +68,18 @@ func (p *Base58Parser) Parse(input string) (*types.IDInfo, error) {
 		"encoding":     "Base58",
 	}
 
-	// Try to detect if it might be a Bitcoin address or similar
-	if len(decoded) == 25 && decoded[0] == 0x00 {
-		extra["possible_type"] = "Bitcoin P2PKH Address"
-	} else if len(decoded) == 25 && decoded[0] == 0x05 {
-		extra["possible_type"] = "Bitcoin P2SH Address"
+	// Only report an address type when the Base58Check checksum verifies
+	if result, ok := verifyBase58Check(decoded); ok && len(decoded) >= 25 {
+		if addressType := describeBase58Check(result, extra); addressType != "" {
+			extra["possible_type"] = addressType
+		} else if len(decoded) >= 32 {
+			extra["possible_type"] = "Hash or Key"
+		}
 	} else if len(decoded) >= 32 {
 		extra["possible_type"] = "Hash or Key"
 	}
 
-	return &types.IDInfo{
+	info := &types.IDInfo{
 		IDType:   "Base58",
 		Standard: input,
 		Size:     len(decoded) * 8,
@@ -85,7 +87,17 @@ func (p *Base58Parser) Parse(input string) (*types.IDInfo, error) {
 		Hex:      fmt.Sprintf("%x", decoded),
 		Binary:   decoded,
 		Extra:    extra,
-	}, nil
+	}
+
+	// Never echo private key material back in hex or binary form
+	if extra["address_type"] == "WIF private key" {
+		info.Secret = true
+		info.Standard = maskSecret(input)
+		info.Hex = ""
+		info.Binary = nil
+	}
+
+	return info, nil
 }
 
 func (p *Base58Parser) Generate() (string, error) {
@@ -0,0 +1,184 @@
+package parsers
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// base58CheckVersion describes a known Base58Check version byte
+type base58CheckVersion struct {
+	network     string
+	addressType string
+}
+
+// Known version bytes for 21-byte (version + hash160) Base58Check payloads
+var base58CheckVersions = map[byte]base58CheckVersion{
+	0x00: {"Bitcoin mainnet", "P2PKH"},
+	0x05: {"Bitcoin mainnet", "P2SH"},
+	0x6f: {"Bitcoin testnet", "P2PKH"},
+	0xc4: {"Bitcoin testnet", "P2SH"},
+	0x30: {"Litecoin mainnet", "P2PKH"},
+	0x32: {"Litecoin mainnet", "P2SH"},
+	0x1e: {"Dogecoin mainnet", "P2PKH"},
+	0x16: {"Dogecoin mainnet", "P2SH"},
+	0x41: {"Tron mainnet", "Account"},
+}
+
+// base58CheckResult holds the outcome of Base58Check verification
+type base58CheckResult struct {
+	version  byte
+	payload  []byte
+	checksum []byte
+	valid    bool
+}
+
+// verifyBase58Check splits decoded Base58 bytes into version, payload and
+// checksum and verifies the double SHA-256 checksum
+func verifyBase58Check(decoded []byte) (*base58CheckResult, bool) {
+	if len(decoded) < 5 {
+		return nil, false
+	}
+
+	body := decoded[:len(decoded)-4]
+	checksum := decoded[len(decoded)-4:]
+	first := sha256.Sum256(body)
+	second := sha256.Sum256(first[:])
+
+	return &base58CheckResult{
+		version:  body[0],
+		payload:  body[1:],
+		checksum: checksum,
+		valid:    bytes.Equal(second[:4], checksum),
+	}, true
+}
+
+// describeBase58Check fills extra with Base58Check details and returns the address type, if known
+func describeBase58Check(result *base58CheckResult, extra map[string]string) string {
+	extra["checksum"] = hex.EncodeToString(result.checksum)
+	extra["checksum_valid"] = fmt.Sprintf("%t", result.valid)
+	extra["version_byte"] = fmt.Sprintf("0x%02x", result.version)
+	extra["payload_hash"] = hex.EncodeToString(result.payload)
+
+	if !result.valid {
+		return ""
+	}
+
+	if known, ok := base58CheckVersions[result.version]; ok && len(result.payload) == 20 {
+		extra["network"] = known.network
+		extra["address_type"] = known.addressType
+		return fmt.Sprintf("%s %s Address", strings.Fields(known.network)[0], known.addressType)
+	}
+
+	// WIF private keys: 32-byte key, optionally followed by the 0x01 compression flag
+	if (result.version == 0x80 || result.version == 0xef) &&
+		(len(result.payload) == 32 || (len(result.payload) == 33 && result.payload[32] == 0x01)) {
+		extra["network"] = "Bitcoin mainnet"
+		if result.version == 0xef {
+			extra["network"] = "Bitcoin testnet"
+		}
+		extra["address_type"] = "WIF private key"
+		extra["payload_hash"] = "(secret, not shown)"
+		return "Bitcoin WIF Private Key"
+	}
+
+	return ""
+}
+
+// Base58CheckParser handles Base58Check payloads whose checksum verifies,
+// such as Bitcoin, Litecoin, Dogecoin and Tron addresses
+type Base58CheckParser struct{}
+
+func (p *Base58CheckParser) Name() string {
+	return "Base58Check"
+}
+
+func (p *Base58CheckParser) CanParse(input string) bool {
+	if len(input) < 25 || len(input) > 60 {
+		return false
+	}
+
+	decoded, err := (&Base58Parser{}).decodeBase58(input)
+	if err != nil {
+		return false
+	}
+
+	result, ok := verifyBase58Check(decoded)
+	return ok && result.valid
+}
+
+func (p *Base58CheckParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+
+	decoded, err := (&Base58Parser{}).decodeBase58(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Base58: %v", err)
+	}
+
+	result, ok := verifyBase58Check(decoded)
+	if !ok {
+		return nil, fmt.Errorf("too short for Base58Check")
+	}
+	if !result.valid {
+		return nil, fmt.Errorf("invalid Base58Check checksum")
+	}
+
+	extra := map[string]string{
+		"encoding":     "Base58Check",
+		"checksum_alg": "double SHA-256 (first 4 bytes)",
+		"decoded_size": fmt.Sprintf("%d bytes", len(decoded)),
+	}
+
+	idType := "Base58Check"
+	if addressType := describeBase58Check(result, extra); addressType != "" {
+		idType = addressType
+	}
+
+	info := &types.IDInfo{
+		IDType:   idType,
+		Standard: input,
+		Size:     len(decoded) * 8,
+		Hex:      hex.EncodeToString(decoded),
+		Binary:   decoded,
+		Extra:    extra,
+	}
+
+	// Never echo private key material back in hex or binary form
+	if extra["address_type"] == "WIF private key" {
+		info.Secret = true
+		info.Standard = maskSecret(input)
+		info.Hex = ""
+		info.Binary = nil
+	}
+
+	// Addresses are hashes of public keys
+	entropy := len(result.payload) * 8
+	info.Entropy = &entropy
+
+	return info, nil
+}
+
+func (p *Base58CheckParser) Generate() (string, error) {
+	// Generate a random Bitcoin mainnet P2PKH-shaped address
+	body := make([]byte, 21)
+	if _, err := rand.Read(body[1:]); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+
+	first := sha256.Sum256(body)
+	second := sha256.Sum256(first[:])
+	return (&Base58Parser{}).encodeBase58(append(body, second[:4]...)), nil
+}
+
+// maskSecret keeps only the first and last four characters of a secret value
+func maskSecret(s string) string {
+	if len(s) <= 12 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
+}
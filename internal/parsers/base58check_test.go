@@ -0,0 +1,68 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBase58CheckParser(t *testing.T) {
+	parser := &Base58CheckParser{}
+
+	tests := []struct {
+		input       string
+		idType      string
+		payloadHash string
+	}{
+		{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "Bitcoin P2PKH Address", "62e907b15cbf27d5425399ebf6f0fb50ebb88f18"},
+		{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", "Bitcoin P2SH Address", "b472a266d0bd89c13706a4132ccfb16f7c3b9fcb"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.IDType != test.idType {
+			t.Errorf("Expected IDType %q, got %q", test.idType, info.IDType)
+		}
+		if info.Extra["payload_hash"] != test.payloadHash {
+			t.Errorf("Expected payload hash %s, got %s", test.payloadHash, info.Extra["payload_hash"])
+		}
+		if info.Extra["checksum_valid"] != "true" {
+			t.Errorf("Expected checksum_valid true for %s", test.input)
+		}
+	}
+
+	// A single changed character breaks the checksum
+	if parser.CanParse("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb") {
+		t.Error("Expected to reject address with bad checksum")
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !strings.HasPrefix(generated, "1") || !parser.CanParse(generated) {
+		t.Errorf("Generated address is not valid: %s", generated)
+	}
+}
+
+func TestBase58Parser_ChecksumReporting(t *testing.T) {
+	parser := &Base58Parser{}
+
+	info, err := parser.Parse("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["checksum_valid"] != "false" {
+		t.Errorf("Expected checksum_valid false, got %q", info.Extra["checksum_valid"])
+	}
+	if _, exists := info.Extra["possible_type"]; exists {
+		t.Errorf("Expected no address type guess for bad checksum, got %q", info.Extra["possible_type"])
+	}
+}
@@ -0,0 +1,203 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/segmentio/ksuid"
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+var base64Regex = regexp.MustCompile(`^[A-Za-z0-9+/_-]+={0,2}$`)
+
+// base64AlphabetNames describes the alphabet decodeBase64 detected
+var base64AlphabetNames = map[string]string{
+	"Base64":              "standard (RFC 4648 section 4, +/)",
+	"Base64URL":           "URL-safe (RFC 4648 section 5, -_)",
+	"Base64 or Base64URL": "characters shared by the standard and URL-safe alphabets",
+}
+
+// Base64Parser handles standard and URL-safe Base64, padded or not. Payloads of 12, 16
+// and 20 bytes are also offered as the IDs of that size (ObjectId, UUID/ULID, KSUID).
+type Base64Parser struct {
+	registry *Registry // Parses embedded binary IDs; set by the registry the parser belongs to
+}
+
+func (p *Base64Parser) Name() string {
+	return "Base64"
+}
+
+func (p *Base64Parser) CanParse(input string) bool {
+	if len(input) < 8 || len(input) > 512 || !base64Regex.MatchString(input) {
+		return false
+	}
+	// Numbers and hex strings decode as Base64 too, but are never meant as such
+	if isDigits(input) || hashHexRegex.MatchString(input) {
+		return false
+	}
+	decoded, _, err := decodeBase64(input)
+	if err != nil {
+		return false
+	}
+	// Plain alphanumerics are only worth claiming when they decode to a known ID size
+	if strings.ContainsAny(input, "+/_-=") {
+		return true
+	}
+	switch len(decoded) {
+	case 12, 16, 20:
+		return true
+	}
+	return false
+}
+
+func (p *Base64Parser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !base64Regex.MatchString(input) {
+		return nil, fmt.Errorf("invalid Base64 format")
+	}
+	decoded, alphabet, err := decodeBase64(input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode Base64: %v", err)
+	}
+
+	body := strings.TrimRight(input, "=")
+	given := len(input) - len(body)
+	expected := (4 - len(body)%4) % 4
+	padding := "none needed"
+	switch {
+	case given == expected && expected > 0:
+		padding = fmt.Sprintf("padded (%d characters)", given)
+	case given == 0 && expected > 0:
+		padding = fmt.Sprintf("unpadded (%d characters omitted)", expected)
+	}
+
+	entropy := len(decoded) * 8
+	extra := map[string]string{
+		"alphabet":     base64AlphabetNames[alphabet],
+		"padding":      padding,
+		"decoded_size": fmt.Sprintf("%d bytes", len(decoded)),
+	}
+	if base64.RawStdEncoding.EncodeToString(decoded) != strings.NewReplacer("-", "+", "_", "/").Replace(body) {
+		extra["trailing_bits"] = "non-zero (not canonical)"
+	}
+	if printable := printableASCII(decoded); printable != "" {
+		extra["decoded_text"] = printable
+	}
+
+	switch len(decoded) {
+	case 8:
+		if len(input) == 11 {
+			extra["possible_type"] = "YouTube video ID (64-bit, 11 characters base64url)"
+		}
+	case 12:
+		extra["possible_type"] = "96-bit identifier (ObjectId size)"
+	case 16:
+		extra["possible_type"] = "128-bit identifier (UUID/ULID size, e.g. MongoDB BinData subtype 4)"
+	case 20:
+		extra["possible_type"] = "160-bit identifier (KSUID or SHA-1 size)"
+	case 32:
+		extra["possible_type"] = "256-bit value (SHA-256 size)"
+	}
+
+	standard := base64.StdEncoding.EncodeToString(decoded)
+	return &types.IDInfo{
+		IDType:   "Base64",
+		Version:  alphabet,
+		Standard: input,
+		Base64:   &standard,
+		Size:     len(decoded) * 8,
+		Entropy:  &entropy,
+		Hex:      hex.EncodeToString(decoded),
+		Binary:   decoded,
+		Extra:    extra,
+		Nested:   p.nested().embeddedIDs(decoded),
+	}, nil
+}
+
+func (p *Base64Parser) Generate() (string, error) {
+	// 16 random bytes as unpadded base64url, a common opaque token shape
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func (p *Base64Parser) useRegistry(r *Registry) {
+	p.registry = r
+}
+
+// nested returns the registry of the parser, or a default one for a parser used on its own
+func (p *Base64Parser) nested() *Registry {
+	if p.registry == nil {
+		return NewRegistry()
+	}
+	return p.registry
+}
+
+// embeddedIDs interprets raw 12, 16 and 20-byte values as the binary IDs of that size
+func (r *Registry) embeddedIDs(raw []byte) []types.NestedID {
+	var nested []types.NestedID
+	switch len(raw) {
+	case 12:
+		nested = append(nested, r.nestedIDs("as ObjectId", hex.EncodeToString(raw), "objectid")...)
+	case 16:
+		u, _ := uuid.FromBytes(raw)
+		nested = append(nested, r.nestedIDs("as UUID", u.String(), "uuid")...)
+		ulid := crockfordEncodeInt(new(big.Int).SetBytes(raw), 26)
+		nested = append(nested, r.nestedIDs("as ULID", ulid, "ulid")...)
+	case 20:
+		if k, err := ksuid.FromBytes(raw); err == nil {
+			nested = append(nested, r.nestedIDs("as KSUID", k.String(), "ksuid")...)
+		}
+	}
+	return nested
+}
+
+// decodeBase64 decodes standard or URL-safe Base64 with or without padding and names the alphabet
+func decodeBase64(input string) ([]byte, string, error) {
+	body := strings.TrimRight(input, "=")
+	if strings.ContainsAny(body, "+/") && strings.ContainsAny(body, "-_") {
+		return nil, "", fmt.Errorf("mixes standard (+/) and URL-safe (-_) characters")
+	}
+	if len(body)%4 == 1 {
+		return nil, "", fmt.Errorf("invalid length %d", len(body))
+	}
+	if given := len(input) - len(body); given > 0 && (len(body)+given)%4 != 0 {
+		return nil, "", fmt.Errorf("invalid padding")
+	}
+
+	alphabet := "Base64 or Base64URL"
+	encoding := base64.RawStdEncoding
+	switch {
+	case strings.ContainsAny(body, "+/"):
+		alphabet = "Base64"
+	case strings.ContainsAny(body, "-_"):
+		alphabet = "Base64URL"
+		encoding = base64.RawURLEncoding
+	}
+	decoded, err := encoding.DecodeString(body)
+	if err != nil {
+		return nil, "", err
+	}
+	return decoded, alphabet, nil
+}
+
+// printableASCII returns the bytes as text when every byte is printable ASCII
+func printableASCII(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return ""
+		}
+	}
+	return string(b)
+}
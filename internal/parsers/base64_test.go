@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestBase64Parser(t *testing.T) {
+	parser := &Base64Parser{}
+
+	tests := []struct {
+		input    string
+		version  string
+		hex      string
+		padding  string
+		nested   []string
+		nestedTo []string
+	}{
+		// A UUIDv7 stored as MongoDB BinData subtype 4
+		{"AZAKXayWd0u8zrMCCZqAVw==", "Base64 or Base64URL", "01900a5dac96774bbcceb302099a8057", "padded (2 characters)",
+			[]string{"as UUID", "as ULID"}, []string{"01900a5d-ac96-774b-bcce-b302099a8057", "01J055VB4PEX5VSKNK084SN02Q"}},
+		{"ZNSQkxxDr2Wd9Q5m", "Base64 or Base64URL", "64d490931c43af659df50e66", "none needed",
+			[]string{"as ObjectId"}, []string{"64d490931c43af659df50e66"}},
+		{"MCWyq7tbGBuy_EWpr6sRv0bVkRo", "Base64URL", "3025b2abbb5b181bb2fc45a9afab11bf46d5911a", "unpadded (1 characters omitted)",
+			[]string{"as KSUID"}, nil},
+		{"aGVsbG8+Pz8/", "Base64", "68656c6c6f3e3f3f3f", "none needed", nil, nil},
+	}
+
+	for _, tt := range tests {
+		if !parser.CanParse(tt.input) {
+			t.Errorf("Expected to detect Base64 %s", tt.input)
+		}
+		info, err := parser.Parse(tt.input)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", tt.input, err)
+			continue
+		}
+		if info.Version != tt.version || info.Hex != tt.hex || info.Extra["padding"] != tt.padding {
+			t.Errorf("%s: got %s / %s / %s", tt.input, info.Version, info.Hex, info.Extra["padding"])
+		}
+		if len(info.Nested) != len(tt.nested) {
+			t.Errorf("%s: expected %d nested IDs, got %d", tt.input, len(tt.nested), len(info.Nested))
+			continue
+		}
+		for i, field := range tt.nested {
+			if info.Nested[i].Field != field || info.Nested[i].Info.DateTime == nil {
+				t.Errorf("%s: expected nested %s with a timestamp, got %+v", tt.input, field, info.Nested[i])
+			}
+			if i < len(tt.nestedTo) && info.Nested[i].Value != tt.nestedTo[i] {
+				t.Errorf("%s: expected nested value %s, got %s", tt.input, tt.nestedTo[i], info.Nested[i].Value)
+			}
+		}
+	}
+
+	info, err := parser.Parse("dQw4w9WgXcQ")
+	if err != nil {
+		t.Fatalf("Failed to parse YouTube ID: %v", err)
+	}
+	if info.Extra["possible_type"] == "" || *info.Base64 != "dQw4w9WgXcQ=" {
+		t.Errorf("Expected YouTube hint and padded form, got %q / %s", info.Extra["possible_type"], *info.Base64)
+	}
+
+	invalid := []string{
+		"abc+def_ghij", // mixes standard and URL-safe alphabets
+		"aGVsbG8gd29ybGQ==",
+		"aGVsbG8gd", // 9 characters cannot be decoded
+	}
+	for _, input := range invalid {
+		if _, err := parser.Parse(input); err == nil {
+			t.Errorf("Expected error for %s", input)
+		}
+	}
+
+	// Digits, hex and plain alphanumerics of an unknown size are left to other parsers
+	for _, input := range []string{"1234567890123456", "deadbeefcafebabe", "helloworld12"} {
+		if parser.CanParse(input) {
+			t.Errorf("Expected not to claim %s", input)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	if info, err := parser.Parse(generated); err != nil || len(info.Binary) != 16 {
+		t.Errorf("Generated value %s does not decode to 16 bytes: %v", generated, err)
+	}
+}
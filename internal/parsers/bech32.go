@@ -0,0 +1,241 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Bech32 data alphabet (BIP-173)
+const bech32Charset = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+
+// Checksum constants for Bech32 (BIP-173) and Bech32m (BIP-350)
+const (
+	bech32Const  = 1
+	bech32mConst = 0x2bc830a3
+)
+
+// Known SegWit human-readable parts
+var bech32Networks = map[string]string{
+	"bc":   "Bitcoin mainnet",
+	"tb":   "Bitcoin testnet",
+	"bcrt": "Bitcoin regtest",
+	"ltc":  "Litecoin mainnet",
+	"tltc": "Litecoin testnet",
+}
+
+// Bech32Parser handles Bech32/Bech32m SegWit addresses
+type Bech32Parser struct{}
+
+func (p *Bech32Parser) Name() string {
+	return "Bech32"
+}
+
+func (p *Bech32Parser) CanParse(input string) bool {
+	if len(input) < 14 || len(input) > 90 {
+		return false
+	}
+
+	// Mixed case is never valid Bech32
+	if strings.ToLower(input) != input && strings.ToUpper(input) != input {
+		return false
+	}
+
+	hrp, data, ok := splitBech32(strings.ToLower(input))
+	if !ok {
+		return false
+	}
+	if _, known := bech32Networks[hrp]; !known {
+		return false
+	}
+	return len(data) >= 7
+}
+
+func (p *Bech32Parser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid Bech32 address format")
+	}
+
+	lower := strings.ToLower(input)
+	hrp, data, _ := splitBech32(lower)
+	values := data[:len(data)-6]
+
+	extra := map[string]string{
+		"hrp":     hrp,
+		"network": bech32Networks[hrp],
+	}
+
+	// Verify the checksum against both constants to tell Bech32 from Bech32m
+	polymod := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	encoding := ""
+	switch polymod {
+	case bech32Const:
+		encoding = "Bech32"
+	case bech32mConst:
+		encoding = "Bech32m"
+	}
+	extra["checksum"] = lower[len(lower)-6:]
+	extra["checksum_valid"] = fmt.Sprintf("%t", encoding != "")
+	if encoding != "" {
+		extra["encoding"] = encoding
+	}
+
+	witnessVersion := int(values[0])
+	program, err := convertBits(values[1:], 5, 8, false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid witness program: %v", err)
+	}
+
+	extra["witness_version"] = fmt.Sprintf("%d", witnessVersion)
+	extra["witness_program"] = hex.EncodeToString(program)
+	extra["payload_hash"] = hex.EncodeToString(program)
+
+	addressType := "Unknown witness program"
+	switch {
+	case witnessVersion == 0 && len(program) == 20:
+		addressType = "P2WPKH"
+	case witnessVersion == 0 && len(program) == 32:
+		addressType = "P2WSH"
+	case witnessVersion == 1 && len(program) == 32:
+		addressType = "P2TR (Taproot)"
+	}
+	extra["address_type"] = addressType
+
+	// BIP-350: version 0 must use Bech32, versions 1-16 must use Bech32m
+	valid := encoding != "" && witnessVersion <= 16 && len(program) >= 2 && len(program) <= 40
+	if witnessVersion == 0 {
+		valid = valid && encoding == "Bech32" && (len(program) == 20 || len(program) == 32)
+	} else {
+		valid = valid && encoding == "Bech32m"
+	}
+	extra["valid_address"] = fmt.Sprintf("%t", valid)
+
+	entropy := len(program) * 8
+
+	return &types.IDInfo{
+		IDType:   fmt.Sprintf("%s SegWit Address (%s)", strings.Fields(bech32Networks[hrp])[0], addressType),
+		Version:  fmt.Sprintf("witness v%d", witnessVersion),
+		Standard: lower,
+		Size:     len(program) * 8,
+		Entropy:  &entropy,
+		Hex:      hex.EncodeToString(program),
+		Binary:   program,
+		Extra:    extra,
+	}, nil
+}
+
+func (p *Bech32Parser) Generate() (string, error) {
+	// Generate a random Bitcoin mainnet P2WPKH-shaped address
+	program := make([]byte, 20)
+	if _, err := rand.Read(program); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	return encodeSegWitAddress("bc", 0, program)
+}
+
+// splitBech32 splits a lowercase Bech32 string at its last '1' separator and
+// maps the data part to 5-bit values
+func splitBech32(s string) (string, []byte, bool) {
+	pos := strings.LastIndexByte(s, '1')
+	if pos < 1 || pos+7 > len(s) {
+		return "", nil, false
+	}
+
+	data := make([]byte, 0, len(s)-pos-1)
+	for _, c := range s[pos+1:] {
+		idx := strings.IndexRune(bech32Charset, c)
+		if idx == -1 {
+			return "", nil, false
+		}
+		data = append(data, byte(idx))
+	}
+	return s[:pos], data, true
+}
+
+// encodeSegWitAddress encodes a witness program using Bech32 (v0) or Bech32m (v1+)
+func encodeSegWitAddress(hrp string, version byte, program []byte) (string, error) {
+	converted, err := convertBits(program, 8, 5, true)
+	if err != nil {
+		return "", err
+	}
+	values := append([]byte{version}, converted...)
+
+	constant := uint32(bech32Const)
+	if version > 0 {
+		constant = bech32mConst
+	}
+	polymod := bech32Polymod(append(append(bech32HRPExpand(hrp), values...), 0, 0, 0, 0, 0, 0)) ^ constant
+
+	var sb strings.Builder
+	sb.WriteString(hrp)
+	sb.WriteByte('1')
+	for _, v := range values {
+		sb.WriteByte(bech32Charset[v])
+	}
+	for i := 0; i < 6; i++ {
+		sb.WriteByte(bech32Charset[(polymod>>uint(5*(5-i)))&31])
+	}
+	return sb.String(), nil
+}
+
+// bech32Polymod computes the BCH checksum defined in BIP-173
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := 0; i < 5; i++ {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32HRPExpand expands the human-readable part for checksum computation
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := 0; i < len(hrp); i++ {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// convertBits regroups a slice of fromBits-wide values into toBits-wide values
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	acc := uint32(0)
+	bits := uint(0)
+	maxv := uint32(1)<<toBits - 1
+	var result []byte
+
+	for _, value := range data {
+		if uint32(value)>>fromBits != 0 {
+			return nil, fmt.Errorf("value %d out of range", value)
+		}
+		acc = acc<<fromBits | uint32(value)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxv))
+		}
+	}
+
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxv))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxv != 0 {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return result, nil
+}
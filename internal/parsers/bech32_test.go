@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestBech32Parser(t *testing.T) {
+	parser := &Bech32Parser{}
+
+	tests := []struct {
+		input          string
+		encoding       string
+		witnessVersion string
+		program        string
+		validAddress   string
+	}{
+		{"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", "Bech32", "0", "751e76e8199196d454941c45d1b3a323f1433bd6", "true"},
+		{"BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", "Bech32", "0", "751e76e8199196d454941c45d1b3a323f1433bd6", "true"},
+		{"bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", "Bech32m", "1", "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", "true"},
+		// Witness v1 encoded with the original Bech32 constant is rejected by BIP-350
+		{"bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7k7grplx", "Bech32", "1", "751e76e8199196d454941c45d1b3a323f1433bd6751e76e8199196d454941c45d1b3a323f1433bd6", "false"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Extra["encoding"] != test.encoding {
+			t.Errorf("%s: expected encoding %s, got %s", test.input, test.encoding, info.Extra["encoding"])
+		}
+		if info.Extra["witness_version"] != test.witnessVersion {
+			t.Errorf("%s: expected witness version %s, got %s", test.input, test.witnessVersion, info.Extra["witness_version"])
+		}
+		if info.Extra["witness_program"] != test.program {
+			t.Errorf("%s: expected program %s, got %s", test.input, test.program, info.Extra["witness_program"])
+		}
+		if info.Extra["valid_address"] != test.validAddress {
+			t.Errorf("%s: expected valid_address %s, got %s", test.input, test.validAddress, info.Extra["valid_address"])
+		}
+	}
+
+	// Corrupted checksum
+	info, err := parser.Parse("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["checksum_valid"] != "false" {
+		t.Errorf("Expected checksum_valid false, got %s", info.Extra["checksum_valid"])
+	}
+
+	invalid := []string{
+		"",
+		"bc1",
+		"Bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", // mixed case
+		"xx1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", // unknown HRP
+		"bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3tb", // 'b' is not in the charset
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	info, err = parser.Parse(generated)
+	if err != nil || info.Extra["valid_address"] != "true" {
+		t.Errorf("Generated address is not valid: %s", generated)
+	}
+}
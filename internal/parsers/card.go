@@ -0,0 +1,160 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// cardBrand describes an IIN range and the PAN lengths the network issues in it
+type cardBrand struct {
+	name     string
+	from, to int // IIN range, compared on the first len(strconv.Itoa(to)) digits
+	lengths  []int
+}
+
+// IIN ranges of the major card networks; more specific ranges come first
+var cardBrands = []cardBrand{
+	{"American Express", 34, 34, []int{15}},
+	{"American Express", 37, 37, []int{15}},
+	{"Diners Club International", 300, 305, []int{14, 16, 17, 18, 19}},
+	{"Diners Club International", 36, 36, []int{14, 15, 16, 17, 18, 19}},
+	{"Diners Club", 38, 39, []int{14, 15, 16, 17, 18, 19}},
+	{"JCB", 3528, 3589, []int{16, 17, 18, 19}},
+	{"Visa Electron", 4026, 4026, []int{16}},
+	{"Visa Electron", 4508, 4508, []int{16}},
+	{"Visa Electron", 4844, 4844, []int{16}},
+	{"Visa Electron", 4913, 4913, []int{16}},
+	{"Visa Electron", 4917, 4917, []int{16}},
+	{"Visa", 4, 4, []int{13, 16, 19}},
+	{"Mastercard", 2221, 2720, []int{16}},
+	{"Mastercard", 51, 55, []int{16}},
+	{"Maestro", 5018, 5018, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 5020, 5020, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 5038, 5038, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 5893, 5893, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 6304, 6304, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 6759, 6759, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Maestro", 6761, 6763, []int{12, 13, 14, 15, 16, 17, 18, 19}},
+	{"Discover", 6011, 6011, []int{16, 17, 18, 19}},
+	{"Discover", 644, 649, []int{16, 17, 18, 19}},
+	{"Discover", 65, 65, []int{16, 17, 18, 19}},
+	{"UnionPay", 62, 62, []int{16, 17, 18, 19}},
+	{"Mir", 2200, 2204, []int{16, 17, 18, 19}},
+	{"RuPay", 60, 60, []int{16}},
+	{"Troy", 9792, 9792, []int{16}},
+}
+
+// Major Industry Identifier: the first digit of a PAN
+var cardIndustries = map[byte]string{
+	'1': "Airlines",
+	'2': "Airlines, financial and future industry assignments",
+	'3': "Travel and entertainment",
+	'4': "Banking and financial",
+	'5': "Banking and financial",
+	'6': "Merchandising and banking/financial",
+	'7': "Petroleum and future industry assignments",
+	'8': "Healthcare, telecommunications and future industry assignments",
+	'9': "National assignment",
+}
+
+// PaymentCardParser handles payment card numbers (PANs) validated with the Luhn algorithm.
+// Card numbers are always masked to the BIN and last four digits.
+type PaymentCardParser struct{}
+
+func (p *PaymentCardParser) Name() string {
+	return "PaymentCard"
+}
+
+func (p *PaymentCardParser) CanParse(input string) bool {
+	pan := stripSeparators(input)
+	if !isDigits(pan) || len(pan) < 12 || len(pan) > 19 || !luhnValid(pan) {
+		return false
+	}
+	// Luhn alone passes one number in ten, so also require a known network and length
+	brand := matchCardBrand(pan)
+	if brand == nil {
+		return false
+	}
+	for _, n := range brand.lengths {
+		if len(pan) == n {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PaymentCardParser) Parse(input string) (*types.IDInfo, error) {
+	pan := stripSeparators(strings.TrimSpace(input))
+	if !isDigits(pan) || len(pan) < 12 || len(pan) > 19 {
+		return nil, fmt.Errorf("card numbers have 12 to 19 digits")
+	}
+	if !luhnValid(pan) {
+		return nil, fmt.Errorf("invalid card number: Luhn check failed")
+	}
+
+	brandName := "unknown network"
+	lengthOK := "unknown"
+	if brand := matchCardBrand(pan); brand != nil {
+		brandName = brand.name
+		lengthOK = "no"
+		for _, n := range brand.lengths {
+			if len(pan) == n {
+				lengthOK = "yes"
+			}
+		}
+	}
+
+	// PCI DSS permits showing at most the first six and last four digits
+	bin, last4 := pan[:6], pan[len(pan)-4:]
+	masked := bin + strings.Repeat("*", len(pan)-10) + last4
+
+	info := &types.IDInfo{
+		IDType:   fmt.Sprintf("Payment card number (%s)", brandName),
+		Version:  fmt.Sprintf("%d digits", len(pan)),
+		Standard: masked,
+		Size:     len(pan) * 4,
+		Node1:    &bin,
+		Node2:    &last4,
+		Secret:   true,
+		Extra: map[string]string{
+			"network":        brandName,
+			"bin":            bin,
+			"last4":          last4,
+			"industry":       fmt.Sprintf("%c (%s)", pan[0], cardIndustries[pan[0]]),
+			"length_valid":   lengthOK,
+			"checksum":       "valid (Luhn)",
+			"secret":         "true (value masked)",
+			"account_digits": fmt.Sprintf("%d", len(pan)-7),
+		},
+	}
+	return info, nil
+}
+
+func (p *PaymentCardParser) Generate() (string, error) {
+	// Generate a Visa number from the 4000 00 test range
+	body, err := randomDigits(9)
+	if err != nil {
+		return "", err
+	}
+	payload := "400000" + body
+	return payload + string(luhnCheckDigit(payload)), nil
+}
+
+// matchCardBrand returns the network whose IIN range contains the PAN
+func matchCardBrand(pan string) *cardBrand {
+	for i := range cardBrands {
+		brand := &cardBrands[i]
+		width := len(strconv.Itoa(brand.to))
+		iin, err := strconv.Atoi(pan[:width])
+		if err != nil {
+			continue
+		}
+		if iin >= brand.from && iin <= brand.to {
+			return brand
+		}
+	}
+	return nil
+}
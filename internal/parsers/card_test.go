@@ -0,0 +1,93 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPaymentCardParser(t *testing.T) {
+	parser := &PaymentCardParser{}
+
+	tests := []struct {
+		input  string
+		brand  string
+		masked string
+	}{
+		{"4111111111111111", "Visa", "411111******1111"},
+		{"4111 1111 1111 1111", "Visa", "411111******1111"},
+		{"5555555555554444", "Mastercard", "555555******4444"},
+		{"2223003122003222", "Mastercard", "222300******3222"},
+		{"378282246310005", "American Express", "378282*****0005"},
+		{"6011111111111117", "Discover", "601111******1117"},
+		{"3530111333300000", "JCB", "353011******0000"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Extra["network"] != test.brand {
+			t.Errorf("%s: expected %s, got %s", test.input, test.brand, info.Extra["network"])
+		}
+		if info.Standard != test.masked || !info.Secret {
+			t.Errorf("%s: expected masked %s, got %s", test.input, test.masked, info.Standard)
+		}
+		if info.Integer != nil || info.Hex != "" || info.Binary != nil {
+			t.Errorf("%s: card number leaked through another representation", test.input)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"4111111111111112",    // Luhn fails
+		"1777150623882019211", // Snowflake: no card network starts with 1
+		"411111111111",        // Visa does not issue 12-digit numbers
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated card number is not valid: %s", generated)
+	}
+}
+
+func TestRegistry_ChecksumParsersOutrankNumeric(t *testing.T) {
+	registry := NewRegistry()
+
+	tests := map[string]string{
+		"4111111111111111": "Payment card number (Visa)",
+		"490154203237518":  "IMEI",
+		"4006381333931":    "GS1 article number",
+		"9780306406157":    "ISBN",
+	}
+	for input, idType := range tests {
+		results := registry.ParseID(input, "")
+		if len(results) == 0 || results[0].IDType != idType {
+			t.Errorf("%s: expected %s first", input, idType)
+		}
+	}
+
+	// Card numbers stay masked in every interpretation unless revealed
+	for _, info := range registry.ParseID("4111111111111111", "") {
+		if strings.Contains(info.Standard, "4111111111111111") {
+			t.Errorf("%s interpretation leaks the card number", info.IDType)
+		}
+	}
+	registry.SetRevealSecrets(true)
+	if results := registry.ParseID("4111111111111111", ""); results[0].Standard != "4111111111111111" {
+		t.Errorf("Expected revealed card number, got %s", results[0].Standard)
+	}
+}
@@ -0,0 +1,185 @@
+package parsers
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Anomaly kinds reported by CheckStream
+const (
+	AnomalyUnparseable     = "unparseable"
+	AnomalyDuplicate       = "duplicate"
+	AnomalyClockRegression = "clock_regression"
+	AnomalyFuture          = "future_timestamp"
+	AnomalyBeforeEpoch     = "before_epoch"
+	AnomalyZeroTimestamp   = "zero_timestamp"
+	AnomalyCounterOverflow = "counter_overflow"
+	AnomalyCounterOrder    = "counter_out_of_order"
+	AnomalyULIDMonotonic   = "ulid_not_monotonic"
+)
+
+// CheckStream checks a stream of IDs of one format for data-quality problems: lines that do not
+// parse, duplicates, timestamps after now+futureTolerance or before the format's epoch, clocks
+// going backwards and counters wrapping or going back within one generator, and ULIDs that are not monotonic
+// within a millisecond. Generators are only known for formats with node components.
+func (r *Registry) CheckStream(inputs []string, forceFormat string, now time.Time, futureTolerance time.Duration) (*types.AnomalyReport, error) {
+	ids, infos, lines, skipped := r.parseCorpus(inputs, forceFormat)
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("no parseable IDs")
+	}
+
+	report := &types.AnomalyReport{Format: infos[0].IDType, Count: len(infos), Counts: map[string]int{}}
+	add := func(a types.Anomaly) {
+		if a.DateTime != nil {
+			a.Timestamp = a.DateTime.UTC().Format(time.RFC3339Nano)
+			if year := a.DateTime.Year(); year < 0 || year > 9999 {
+				a.DateTime = nil
+			}
+		}
+		report.Findings = append(report.Findings, a)
+		report.Counts[a.Kind]++
+	}
+	for _, line := range skipped {
+		// A line that does not parse may still be a token or password, so it is masked as well
+		id := strings.TrimSpace(inputs[line-1])
+		if !r.revealSecrets {
+			id = maskSecret(id)
+		}
+		add(types.Anomaly{Line: line, Kind: AnomalyUnparseable, ID: id,
+			Message: fmt.Sprintf("not a valid %s", report.Format)})
+	}
+
+	// Per generator: the line holding its latest timestamp, and the previous ID in stream order
+	type generatorState struct {
+		latest, previous int
+	}
+	firstSeen := map[string]int{}
+	generators := map[string]*generatorState{}
+	lastULID := -1
+
+	for i, info := range infos {
+		line := lines[i]
+		id := ids[i]
+		if info.Secret && !r.revealSecrets {
+			id = info.Standard
+		}
+
+		if first, ok := firstSeen[ids[i]]; ok {
+			add(types.Anomaly{Line: line, Kind: AnomalyDuplicate, ID: id, RelatedLine: first,
+				Message: fmt.Sprintf("duplicate of line %d", first)})
+		} else {
+			firstSeen[ids[i]] = line
+		}
+
+		if info.DateTime == nil {
+			continue
+		}
+		t := *info.DateTime
+		future := t.After(now.Add(futureTolerance))
+		if future {
+			add(types.Anomaly{Line: line, Kind: AnomalyFuture, ID: id, DateTime: &t,
+				Message: fmt.Sprintf("timestamp %s is %s in the future", t.UTC().Format(time.RFC3339Nano), futureGap(t, now))})
+		}
+		epoch := formatEpoch(info)
+		switch {
+		case t.Before(epoch):
+			add(types.Anomaly{Line: line, Kind: AnomalyBeforeEpoch, ID: id, DateTime: &t,
+				Message: fmt.Sprintf("timestamp %s is before the format's epoch %s", t.UTC().Format(time.RFC3339Nano), epoch.Format(time.RFC3339))})
+		case t.Equal(epoch):
+			add(types.Anomaly{Line: line, Kind: AnomalyZeroTimestamp, ID: id, DateTime: &t,
+				Message: "timestamp is zero: the generator's clock was not set"})
+		}
+
+		if strings.HasPrefix(info.IDType, "ULID") {
+			if lastULID >= 0 && infos[lastULID].DateTime.Equal(t) && strings.ToUpper(info.Standard) <= strings.ToUpper(infos[lastULID].Standard) {
+				add(types.Anomaly{Line: line, Kind: AnomalyULIDMonotonic, ID: id, DateTime: &t, RelatedLine: lines[lastULID],
+					Message: fmt.Sprintf("not greater than the ULID on line %d from the same millisecond", lines[lastULID])})
+			}
+			lastULID = i
+		}
+
+		// An impossible timestamp would make every later ID of its generator look like a regression
+		if future || !t.After(epoch) || info.Node1 == nil && info.Node2 == nil {
+			continue
+		}
+		key := generatorKey(info)
+		state, ok := generators[key]
+		if !ok {
+			generators[key] = &generatorState{latest: i, previous: i}
+			continue
+		}
+		latest := infos[state.latest]
+		if t.Before(*latest.DateTime) {
+			add(types.Anomaly{Line: line, Kind: AnomalyClockRegression, ID: id, DateTime: &t, RelatedLine: lines[state.latest], Generator: key,
+				Message: fmt.Sprintf("clock went back %s from line %d on generator %s", latest.DateTime.Sub(t), lines[state.latest], key)})
+		} else {
+			state.latest = i
+		}
+		previous := infos[state.previous]
+		if info.Sequence != nil && previous.Sequence != nil && previous.DateTime.Equal(t) && *info.Sequence < *previous.Sequence {
+			if counterWrapped(info, *previous.Sequence, *info.Sequence) {
+				add(types.Anomaly{Line: line, Kind: AnomalyCounterOverflow, ID: id, DateTime: &t, RelatedLine: lines[state.previous], Generator: key,
+					Message: fmt.Sprintf("counter wrapped from %d to %d within one tick on generator %s", *previous.Sequence, *info.Sequence, key)})
+			} else {
+				add(types.Anomaly{Line: line, Kind: AnomalyCounterOrder, ID: id, DateTime: &t, RelatedLine: lines[state.previous], Generator: key,
+					Message: fmt.Sprintf("counter went back from %d to %d within one tick on generator %s (IDs out of order)", *previous.Sequence, *info.Sequence, key)})
+			}
+		}
+		state.previous = i
+	}
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		return report.Findings[i].Line < report.Findings[j].Line
+	})
+	return report, nil
+}
+
+// counterWrapped reports whether a counter going from previous to current is an overflow: previous
+// in the top eighth of the counter's range and current in the bottom eighth. The range comes from
+// the parser's counter_bits or sequence_bits detail; without one no drop counts as a wrap.
+func counterWrapped(info *types.IDInfo, previous, current int64) bool {
+	bits, err := strconv.Atoi(info.Extra["counter_bits"])
+	if err != nil {
+		if bits, err = strconv.Atoi(info.Extra["sequence_bits"]); err != nil {
+			return false
+		}
+	}
+	size := int64(1) << bits
+	return previous >= size-size/8 && current < size/8
+}
+
+// futureGap describes how far t is ahead of now; beyond what time.Duration can hold (about 292
+// years) it is given in years
+func futureGap(t, now time.Time) string {
+	if t.Before(now.AddDate(200, 0, 0)) {
+		return t.Sub(now).Round(time.Second).String()
+	}
+	return fmt.Sprintf("%.0f years", float64(t.Unix()-now.Unix())/secondsPerYear)
+}
+
+// formatEpoch returns the earliest time the format can express, from the parser's "epoch" detail
+// when it reports one and the Unix epoch otherwise
+func formatEpoch(info *types.IDInfo) time.Time {
+	if value, ok := info.Extra["epoch"]; ok {
+		if epoch, err := time.Parse(time.RFC3339Nano, strings.Fields(value)[0]); err == nil {
+			return epoch
+		}
+	}
+	return time.Unix(0, 0).UTC()
+}
+
+// generatorKey names the generator of an ID by its node components
+func generatorKey(info *types.IDInfo) string {
+	gen := &types.GeneratorStat{}
+	if info.Node1 != nil {
+		gen.Node1 = *info.Node1
+	}
+	if info.Node2 != nil {
+		gen.Node2 = *info.Node2
+	}
+	return generatorName(gen)
+}
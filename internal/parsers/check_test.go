@@ -0,0 +1,130 @@
+package parsers
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// checkKinds returns the anomaly kinds found per line
+func checkKinds(t *testing.T, inputs []string, format string, now time.Time) map[int][]string {
+	t.Helper()
+	report, err := NewRegistry().CheckStream(inputs, format, now, time.Minute)
+	if err != nil {
+		t.Fatalf("CheckStream failed: %v", err)
+	}
+	kinds := map[int][]string{}
+	for _, a := range report.Findings {
+		kinds[a.Line] = append(kinds[a.Line], a.Kind)
+	}
+	return kinds
+}
+
+func TestCheckStream_Snowflake(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	inputs := []string{
+		"2097152000000008191", // node 1, sequence 4095
+		"2097152000000004096", // same millisecond, sequence back to 0: the counter overflowed
+		"",
+		"2097151999979032583", // 5ms earlier on the same node
+		"2097152000000004096", // repeat of line 2
+		"not-a-snowflake",
+		"4096", // node 1 at the Snowflake epoch itself
+	}
+	kinds := checkKinds(t, inputs, "snowflake", now)
+
+	expected := map[int][]string{
+		2: {AnomalyCounterOverflow},
+		4: {AnomalyClockRegression},
+		5: {AnomalyDuplicate},
+		6: {AnomalyUnparseable},
+		7: {AnomalyZeroTimestamp},
+	}
+	for line, want := range expected {
+		if len(kinds[line]) == 0 || kinds[line][0] != want[0] {
+			t.Errorf("Line %d: expected %v, got %v", line, want, kinds[line])
+		}
+	}
+	if len(kinds[1]) > 0 || len(kinds[3]) > 0 {
+		t.Errorf("Expected no findings on lines 1 and 3, got %v / %v", kinds[1], kinds[3])
+	}
+}
+
+func TestCheckStream_CounterOrder(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	// Same millisecond and node: sequence 5 then 3 is reordering, not an overflow
+	kinds := checkKinds(t, []string{"2097152000000004101", "2097152000000004099"}, "snowflake", now)
+	if len(kinds[2]) != 1 || kinds[2][0] != AnomalyCounterOrder {
+		t.Errorf("Expected an out-of-order counter on line 2, got %v", kinds[2])
+	}
+}
+
+func TestCheckStream_FarFuture(t *testing.T) {
+	// The largest ULID lies in year 10889, beyond time.Duration and beyond what JSON can encode
+	report, err := NewRegistry().CheckStream([]string{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ"}, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Minute)
+	if err != nil {
+		t.Fatalf("CheckStream failed: %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Kind != AnomalyFuture {
+		t.Fatalf("Expected a future timestamp, got %v", report.Findings)
+	}
+	a := report.Findings[0]
+	if a.DateTime != nil || !strings.HasPrefix(a.Timestamp, "10889-") {
+		t.Errorf("Expected no DateTime and a year 10889 timestamp, got %v / %q", a.DateTime, a.Timestamp)
+	}
+	if !strings.Contains(a.Message, "8865 years in the future") {
+		t.Errorf("Expected the gap in years, got %q", a.Message)
+	}
+	if _, err := json.Marshal(report); err != nil {
+		t.Errorf("Expected the report to encode as JSON: %v", err)
+	}
+}
+
+func TestCheckStream_FutureAndULID(t *testing.T) {
+	// 01ARZ3NDEK is 2016-07-30; checked as of 2015 it lies in the future
+	inputs := []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAA", "01ARZ3NDEKTSV4RRFFQ69G5FAW"}
+	kinds := checkKinds(t, inputs, "", time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(kinds[1]) != 1 || kinds[1][0] != AnomalyFuture {
+		t.Errorf("Expected a future timestamp on line 1, got %v", kinds[1])
+	}
+	if len(kinds[2]) != 2 || kinds[2][1] != AnomalyULIDMonotonic {
+		t.Errorf("Expected a future and non-monotonic ULID on line 2, got %v", kinds[2])
+	}
+
+	kinds = checkKinds(t, inputs, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if len(kinds) != 1 || len(kinds[2]) != 1 {
+		t.Errorf("Expected only the non-monotonic ULID, got %v", kinds)
+	}
+}
+
+func TestCheckStream_MasksUnparseable(t *testing.T) {
+	inputs := []string{"507f1f77bcf86cd799439011", "hunter2-password-123", "507f1f77bcf86cd799439012"}
+	registry := NewRegistry()
+	report, err := registry.CheckStream(inputs, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Minute)
+	if err != nil {
+		t.Fatalf("CheckStream failed: %v", err)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].ID != "hunt************-123" {
+		t.Errorf("Expected the unparseable line masked, got %+v", report.Findings)
+	}
+
+	registry.SetRevealSecrets(true)
+	report, _ = registry.CheckStream(inputs, "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Minute)
+	if len(report.Findings) != 1 || report.Findings[0].ID != inputs[1] {
+		t.Errorf("Expected the line in full with --reveal-secrets, got %+v", report.Findings)
+	}
+}
+
+func TestCheckStream_Clean(t *testing.T) {
+	report, err := NewRegistry().CheckStream(fleetObjectIDs(), "", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Minute)
+	if err != nil {
+		t.Fatalf("CheckStream failed: %v", err)
+	}
+	if len(report.Findings) != 0 || report.Count != 300 {
+		t.Errorf("Expected 300 clean IDs, got %d with findings %v", report.Count, report.Findings)
+	}
+	if _, err := NewRegistry().CheckStream([]string{"", "  "}, "", time.Now(), time.Minute); err == nil {
+		t.Error("Expected an error for a stream without IDs")
+	}
+}
@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// stripSeparators removes the spaces and hyphens people use to group digits
+func stripSeparators(input string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(input)
+}
+
+// isDigits reports whether s is a non-empty string of ASCII digits
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// luhnCheckDigit computes the mod-10 (Luhn) check digit for a payload
+func luhnCheckDigit(payload string) byte {
+	sum := 0
+	double := true
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// luhnValid reports whether the last digit is the Luhn check digit of the rest
+func luhnValid(digits string) bool {
+	return len(digits) >= 2 && isDigits(digits) && luhnCheckDigit(digits[:len(digits)-1]) == digits[len(digits)-1]
+}
+
+// gs1CheckDigit computes the GS1 (EAN/UPC/GTIN) check digit: weights 3 and 1 from the right
+func gs1CheckDigit(payload string) byte {
+	sum := 0
+	for i := len(payload) - 1; i >= 0; i-- {
+		d := int(payload[i] - '0')
+		if (len(payload)-1-i)%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
+
+// randomDigits returns n cryptographically random decimal digits
+func randomDigits(n int) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < n; i++ {
+		d, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random digit: %v", err)
+		}
+		sb.WriteByte(byte('0' + d.Int64()))
+	}
+	return sb.String(), nil
+}
+
+// digitsBinary returns the hex and big-endian bytes of a decimal string that fits in 64 bits
+func digitsBinary(digits string) (string, []byte) {
+	n, ok := new(big.Int).SetString(digits, 10)
+	if !ok || n.BitLen() > 64 {
+		return "", nil
+	}
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, n.Uint64())
+	return fmt.Sprintf("%016x", n.Uint64()), raw
+}
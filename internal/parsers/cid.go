@@ -0,0 +1,232 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Multibase prefixes supported for CIDv1 strings
+var multibasePrefixes = map[byte]string{
+	'b': "base32",
+	'B': "base32upper",
+	'z': "base58btc",
+	'f': "base16",
+	'F': "base16upper",
+	'k': "base36",
+	'K': "base36upper",
+	'm': "base64",
+	'u': "base64url",
+}
+
+// Common multicodec content types
+var multicodecNames = map[uint64]string{
+	0x55:   "raw",
+	0x70:   "dag-pb",
+	0x71:   "dag-cbor",
+	0x72:   "libp2p-key",
+	0x78:   "git-raw",
+	0x90:   "eth-block",
+	0x0129: "dag-json",
+	0x0200: "json",
+}
+
+// Common multihash functions with their expected digest lengths
+var multihashFunctions = map[uint64]struct {
+	name   string
+	length int
+}{
+	0x00:   {"identity", 0},
+	0x11:   {"sha1", 20},
+	0x12:   {"sha2-256", 32},
+	0x13:   {"sha2-512", 64},
+	0x16:   {"sha3-256", 32},
+	0x14:   {"sha3-512", 64},
+	0x1b:   {"keccak-256", 32},
+	0x1e:   {"blake3", 32},
+	0xb220: {"blake2b-256", 32},
+}
+
+// CIDParser handles IPFS content identifiers (CIDv0 and multibase CIDv1)
+type CIDParser struct{}
+
+func (p *CIDParser) Name() string {
+	return "CID"
+}
+
+func (p *CIDParser) CanParse(input string) bool {
+	_, err := decodeCID(input)
+	return err == nil
+}
+
+// cidInfo holds the decoded parts of a CID
+type cidInfo struct {
+	version   int
+	multibase string
+	codec     uint64
+	hashCode  uint64
+	digest    []byte
+	raw       []byte
+}
+
+func (p *CIDParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+
+	cid, err := decodeCID(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CID: %v", err)
+	}
+
+	extra := map[string]string{
+		"multibase":           cid.multibase,
+		"cid_version":         fmt.Sprintf("%d", cid.version),
+		"multicodec":          multicodecName(cid.codec),
+		"multicodec_code":     fmt.Sprintf("0x%x", cid.codec),
+		"multihash_function":  multihashName(cid.hashCode),
+		"multihash_code":      fmt.Sprintf("0x%x", cid.hashCode),
+		"multihash_length":    fmt.Sprintf("%d bytes", len(cid.digest)),
+		"multihash_digest":    hex.EncodeToString(cid.digest),
+		"digest_length_valid": "true",
+		"specification":       "https://github.com/multiformats/cid",
+	}
+	if known, ok := multihashFunctions[cid.hashCode]; ok && known.length > 0 && known.length != len(cid.digest) {
+		extra["digest_length_valid"] = "false"
+	}
+	if cid.version == 0 {
+		extra["cidv1_equivalent"] = encodeCIDv1Base32(cid.codec, cid.hashCode, cid.digest)
+	}
+
+	entropy := len(cid.digest) * 8
+
+	return &types.IDInfo{
+		IDType:   "IPFS CID (Content Identifier)",
+		Version:  fmt.Sprintf("%d", cid.version),
+		Standard: input,
+		Size:     len(cid.raw) * 8,
+		Entropy:  &entropy,
+		Hex:      hex.EncodeToString(cid.raw),
+		Binary:   cid.raw,
+		Extra:    extra,
+	}, nil
+}
+
+func (p *CIDParser) Generate() (string, error) {
+	// Generate a CIDv1 of random raw content
+	content := make([]byte, 32)
+	if _, err := rand.Read(content); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	digest := sha256.Sum256(content)
+	return encodeCIDv1Base32(0x55, 0x12, digest[:]), nil
+}
+
+// decodeCID decodes a CIDv0 (Qm...) or multibase CIDv1 string
+func decodeCID(input string) (*cidInfo, error) {
+	// CIDv0: base58btc sha2-256 multihash, always 46 characters starting with "Qm"
+	if len(input) == 46 && strings.HasPrefix(input, "Qm") {
+		raw, err := (&Base58Parser{}).decodeBase58(input)
+		if err != nil {
+			return nil, err
+		}
+		if len(raw) != 34 || raw[0] != 0x12 || raw[1] != 0x20 {
+			return nil, fmt.Errorf("not a sha2-256 multihash")
+		}
+		return &cidInfo{version: 0, multibase: "base58btc (implicit)", codec: 0x70, hashCode: 0x12, digest: raw[2:], raw: raw}, nil
+	}
+
+	if len(input) < 8 {
+		return nil, fmt.Errorf("too short")
+	}
+	base, ok := multibasePrefixes[input[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown multibase prefix %q", input[0])
+	}
+
+	raw, err := decodeMultibase(base, input[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	version, n := binary.Uvarint(raw)
+	if n <= 0 || version != 1 {
+		return nil, fmt.Errorf("unsupported CID version")
+	}
+	rest := raw[n:]
+
+	codec, n := binary.Uvarint(rest)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid multicodec")
+	}
+	rest = rest[n:]
+	if _, known := multicodecNames[codec]; !known {
+		return nil, fmt.Errorf("unknown multicodec 0x%x", codec)
+	}
+
+	hashCode, n := binary.Uvarint(rest)
+	if n <= 0 {
+		return nil, fmt.Errorf("invalid multihash function")
+	}
+	rest = rest[n:]
+
+	length, n := binary.Uvarint(rest)
+	if n <= 0 || uint64(len(rest)-n) != length {
+		return nil, fmt.Errorf("multihash length mismatch")
+	}
+
+	return &cidInfo{version: 1, multibase: base, codec: codec, hashCode: hashCode, digest: rest[n:], raw: raw}, nil
+}
+
+// decodeMultibase decodes the body of a multibase string
+func decodeMultibase(base, body string) ([]byte, error) {
+	switch base {
+	case "base32", "base32upper":
+		return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(strings.ToUpper(body))
+	case "base58btc":
+		return (&Base58Parser{}).decodeBase58(body)
+	case "base16", "base16upper":
+		return hex.DecodeString(body)
+	case "base36", "base36upper":
+		n, ok := new(big.Int).SetString(strings.ToLower(body), 36)
+		if !ok {
+			return nil, fmt.Errorf("invalid base36")
+		}
+		return n.Bytes(), nil
+	case "base64":
+		return base64.RawStdEncoding.DecodeString(body)
+	case "base64url":
+		return base64.RawURLEncoding.DecodeString(body)
+	}
+	return nil, fmt.Errorf("unsupported multibase %s", base)
+}
+
+// encodeCIDv1Base32 encodes a CIDv1 in its canonical lowercase base32 form
+func encodeCIDv1Base32(codec, hashCode uint64, digest []byte) string {
+	raw := binary.AppendUvarint(nil, 1)
+	raw = binary.AppendUvarint(raw, codec)
+	raw = binary.AppendUvarint(raw, hashCode)
+	raw = binary.AppendUvarint(raw, uint64(len(digest)))
+	raw = append(raw, digest...)
+	return "b" + strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(raw))
+}
+
+func multicodecName(code uint64) string {
+	if name, ok := multicodecNames[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown (0x%x)", code)
+}
+
+func multihashName(code uint64) string {
+	if known, ok := multihashFunctions[code]; ok {
+		return known.name
+	}
+	return fmt.Sprintf("unknown (0x%x)", code)
+}
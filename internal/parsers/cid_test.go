@@ -0,0 +1,139 @@
+package parsers
+
+import (
+	"crypto/sha256"
+	"strings"
+	"testing"
+)
+
+func TestCIDParser_CIDv0(t *testing.T) {
+	parser := &CIDParser{}
+	input := "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbdG"
+
+	if !parser.CanParse(input) {
+		t.Fatalf("Expected to parse CIDv0 %s", input)
+	}
+
+	info, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if info.Version != "0" {
+		t.Errorf("Expected version 0, got %s", info.Version)
+	}
+	if info.Extra["multicodec"] != "dag-pb" {
+		t.Errorf("Expected dag-pb, got %s", info.Extra["multicodec"])
+	}
+	if info.Extra["multihash_function"] != "sha2-256" || info.Extra["multihash_length"] != "32 bytes" {
+		t.Errorf("Expected sha2-256/32 bytes, got %s/%s", info.Extra["multihash_function"], info.Extra["multihash_length"])
+	}
+
+	// The CIDv1 equivalent must decode to the same digest
+	v1 := info.Extra["cidv1_equivalent"]
+	if !strings.HasPrefix(v1, "bafybei") {
+		t.Errorf("Expected dag-pb CIDv1 to start with bafybei, got %s", v1)
+	}
+	v1Info, err := parser.Parse(v1)
+	if err != nil {
+		t.Fatalf("Failed to parse CIDv1 equivalent: %v", err)
+	}
+	if v1Info.Extra["multihash_digest"] != info.Extra["multihash_digest"] {
+		t.Error("CIDv1 equivalent has a different digest")
+	}
+}
+
+func TestCIDParser_CIDv1(t *testing.T) {
+	parser := &CIDParser{}
+
+	digest := sha256.Sum256([]byte("hello world"))
+	base32CID := encodeCIDv1Base32(0x55, 0x12, digest[:])
+	if !strings.HasPrefix(base32CID, "bafkrei") {
+		t.Errorf("Expected raw CIDv1 to start with bafkrei, got %s", base32CID)
+	}
+
+	info, err := parser.Parse(base32CID)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Version != "1" || info.Extra["multibase"] != "base32" || info.Extra["multicodec"] != "raw" {
+		t.Errorf("Unexpected CIDv1 details: version=%s multibase=%s codec=%s", info.Version, info.Extra["multibase"], info.Extra["multicodec"])
+	}
+
+	// Same CID in base16 multibase
+	hexCID := "f" + info.Hex
+	hexInfo, err := parser.Parse(hexCID)
+	if err != nil {
+		t.Fatalf("Failed to parse base16 CID: %v", err)
+	}
+	if hexInfo.Extra["multibase"] != "base16" || hexInfo.Extra["multihash_digest"] != info.Extra["multihash_digest"] {
+		t.Errorf("Base16 CID decoded differently: %v", hexInfo.Extra)
+	}
+
+	invalid := []string{"", "bafy", "QmYwAPJzv5CZsnA625s3Xf2nemtYgPpHdWEz79ojWnPbd", "xafkreigh2akiscaildc", "hello-world-id"}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated CID is not valid: %s", generated)
+	}
+}
+
+func TestGitOIDParser(t *testing.T) {
+	parser := &GitOIDParser{}
+
+	if !parser.CanParse("e69de29bb2d1d6434b8b29ae775ad8c2e48c5391") {
+		t.Error("Expected to parse SHA-1 object ID")
+	}
+	if parser.CanParse("e69de29bb2d1d6434b8b29ae775ad8c2e48c539") {
+		t.Error("Expected to reject 39 hex characters")
+	}
+
+	info, err := parser.Parse("473a0f4c3be8a93681a267e3b1e9a7dcda1185436fe141f7749120a303721813")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["hash_algorithm"] != "SHA-256" {
+		t.Errorf("Expected SHA-256, got %s", info.Extra["hash_algorithm"])
+	}
+
+	// Well-known IDs of the empty blob
+	if id := GitBlobObjectID(nil, "sha1"); id != "e69de29bb2d1d6434b8b29ae775ad8c2e48c5391" {
+		t.Errorf("Unexpected SHA-1 empty blob ID: %s", id)
+	}
+	if id := GitBlobObjectID(nil, "sha256"); id != "473a0f4c3be8a93681a267e3b1e9a7dcda1185436fe141f7749120a303721813" {
+		t.Errorf("Unexpected SHA-256 empty blob ID: %s", id)
+	}
+}
+
+func TestMatchGitBlob(t *testing.T) {
+	content := []byte("hello\n")
+	tests := []struct {
+		expected string
+		match    bool
+		wantErr  bool
+	}{
+		{"ce013625030ba8dba906f756967f9e9ca394464a", true, false}, // full SHA-1 of "hello\n"
+		{"CE0136", true, false},
+		{"e69de29bb2d1d6434b8b29ae775ad8c2e48c5391", false, false}, // the empty blob
+		{"ce0", false, true},
+		{"", false, true},
+		{"ce01zz", false, true},
+	}
+	for _, tt := range tests {
+		match, err := MatchGitBlob(content, tt.expected)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MatchGitBlob(%q) error = %v, wantErr %v", tt.expected, err, tt.wantErr)
+		}
+		if match != tt.match {
+			t.Errorf("MatchGitBlob(%q) = %v, want %v", tt.expected, match, tt.match)
+		}
+	}
+}
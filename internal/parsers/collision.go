@@ -0,0 +1,227 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// DefaultCollisionThreshold is the risk level reported when none is given
+const DefaultCollisionThreshold = 0.01
+
+const secondsPerYear = 365.25 * 24 * 3600
+
+// collisionUnits maps rate and period units to seconds
+var collisionUnits = map[string]float64{
+	"ms": 0.001, "s": 1, "sec": 1, "second": 1,
+	"m": 60, "min": 60, "minute": 60,
+	"h": 3600, "hr": 3600, "hour": 3600,
+	"d": 86400, "day": 86400,
+	"w": 7 * 86400, "week": 7 * 86400,
+	"mo": secondsPerYear / 12, "month": secondsPerYear / 12,
+	"y": secondsPerYear, "yr": secondsPerYear, "year": secondsPerYear,
+}
+
+// CollisionProbability returns the birthday-bound probability that count IDs with the given
+// number of random bits contain at least one duplicate: 1 - exp(-n(n-1) / 2^(bits+1))
+func CollisionProbability(bits int, count float64) float64 {
+	if count < 2 {
+		return 0
+	}
+	exponent := count * (count - 1) / 2 / math.Exp2(float64(bits))
+	return -math.Expm1(-exponent)
+}
+
+// CollisionCapacity returns how many IDs can be generated before the collision probability
+// reaches threshold: sqrt(2 * 2^bits * ln(1 / (1 - threshold)))
+func CollisionCapacity(bits int, threshold float64) float64 {
+	return math.Exp2(float64(bits)/2) * math.Sqrt(-2*math.Log1p(-threshold))
+}
+
+// EstimateCollision fills in a collision risk report. Count and ratePerSecond are optional;
+// with only a rate, the count is what that rate produces over periodSeconds.
+func EstimateCollision(bits int, threshold, count, ratePerSecond, periodSeconds float64) *types.CollisionRisk {
+	if threshold <= 0 || threshold >= 1 {
+		threshold = DefaultCollisionThreshold
+	}
+	capacity := CollisionCapacity(bits, threshold)
+	risk := &types.CollisionRisk{
+		RandomBits:     bits,
+		Threshold:      threshold,
+		IDsAtThreshold: capacity,
+		Summary:        fmt.Sprintf("%s risk after %s IDs", FormatPercent(threshold), FormatIDCount(capacity)),
+	}
+	if ratePerSecond > 0 {
+		risk.RatePerSecond = ratePerSecond
+		risk.SecondsToThreshold = capacity / ratePerSecond
+		if count == 0 && periodSeconds > 0 {
+			count = ratePerSecond * periodSeconds
+			risk.PeriodSeconds = periodSeconds
+		}
+	}
+	if count > 0 {
+		p := CollisionProbability(bits, count)
+		risk.Count = count
+		risk.Probability = &p
+	}
+	return risk
+}
+
+// TimestampTick is the resolution of a time-based ID's timestamp
+type TimestampTick struct {
+	Label   string
+	Seconds float64
+}
+
+// timestampTicks maps the timestamp_precision parsers report to their tick
+var timestampTicks = map[string]TimestampTick{
+	"second":          {"second", 1},
+	"10 milliseconds": {"10 ms", 0.01},
+	"millisecond":     {"millisecond", 0.001},
+	"microsecond":     {"microsecond", 1e-6},
+	"100 nanoseconds": {"100 ns", 1e-7},
+}
+
+// TimestampTickOf returns the tick of an ID's timestamp, if it has one and its parser reports it
+func TimestampTickOf(info *types.IDInfo) (TimestampTick, bool) {
+	if info.DateTime == nil {
+		return TimestampTick{}, false
+	}
+	tick, ok := timestampTicks[info.Extra["timestamp_precision"]]
+	return tick, ok
+}
+
+// EstimateTickCollision fills in a collision risk report for time-based IDs, whose random bits
+// only have to tell apart the IDs of one timestamp tick. IDsAtThreshold is per tick. With a rate
+// of λ IDs per tick, each tick collides with probability 1 - exp(-λ²/2^(bits+1)), and the ticks
+// of the period (or of count IDs) combine as 1 - (1 - p_tick)^ticks.
+func EstimateTickCollision(bits int, threshold, count, ratePerSecond, periodSeconds float64, tick TimestampTick) *types.CollisionRisk {
+	if threshold <= 0 || threshold >= 1 {
+		threshold = DefaultCollisionThreshold
+	}
+	capacity := CollisionCapacity(bits, threshold)
+	risk := &types.CollisionRisk{
+		RandomBits:     bits,
+		Threshold:      threshold,
+		IDsAtThreshold: capacity,
+		Summary:        fmt.Sprintf("%s risk after %s IDs per %s", FormatPercent(threshold), FormatIDCount(capacity), tick.Label),
+		Tick:           tick.Label,
+		TickSeconds:    tick.Seconds,
+	}
+	if ratePerSecond <= 0 {
+		return risk
+	}
+
+	perTick := ratePerSecond * tick.Seconds
+	// Expected colliding pairs in one tick
+	pairsPerTick := perTick * perTick / 2 / math.Exp2(float64(bits))
+	risk.RatePerSecond = ratePerSecond
+	risk.SecondsToThreshold = -math.Log1p(-threshold) / pairsPerTick * tick.Seconds
+	if count == 0 && periodSeconds > 0 {
+		count = ratePerSecond * periodSeconds
+		risk.PeriodSeconds = periodSeconds
+	}
+	if count > 0 {
+		ticks := count / perTick
+		p := -math.Expm1(-ticks * pairsPerTick)
+		risk.Count = count
+		risk.Probability = &p
+	}
+	return risk
+}
+
+// ParseCollisionRate parses a generation rate such as "1000/s", "50/min", "2e6/day" or a bare
+// number of IDs per second, returning IDs per second
+func ParseCollisionRate(s string) (float64, error) {
+	number, unit, found := strings.Cut(strings.TrimSpace(s), "/")
+	n, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+	if err != nil || n <= 0 || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("invalid rate '%s' (use e.g. 1000/s, 50/min, 2e6/day)", s)
+	}
+	if !found {
+		return n, nil
+	}
+	unit = strings.ToLower(strings.TrimSpace(unit))
+	seconds, ok := collisionUnits[unit]
+	if !ok {
+		seconds, ok = collisionUnits[strings.TrimSuffix(unit, "s")]
+	}
+	if !ok {
+		return 0, fmt.Errorf("unknown rate unit '%s' (use ms, s, min, h, day, week, month or year)", unit)
+	}
+	return n / seconds, nil
+}
+
+// ParseCollisionPeriod parses a period such as "90d", "1y", "6 months" or "36h" into seconds
+func ParseCollisionPeriod(s string) (float64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	i := strings.IndexFunc(s, func(r rune) bool { return r >= 'a' && r <= 'z' })
+	if i <= 0 {
+		return 0, fmt.Errorf("invalid period '%s' (use e.g. 36h, 90d, 1y)", s)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s[:i]), 64)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid period '%s' (use e.g. 36h, 90d, 1y)", s)
+	}
+	unit := strings.TrimSpace(s[i:])
+	seconds, ok := collisionUnits[unit]
+	if !ok {
+		seconds, ok = collisionUnits[strings.TrimSuffix(unit, "s")]
+	}
+	if !ok {
+		return 0, fmt.Errorf("unknown period unit '%s' (use s, min, h, d, w, mo or y)", unit)
+	}
+	return n * seconds, nil
+}
+
+// ParseCollisionThreshold parses a probability given as "1%" or "0.01"
+func ParseCollisionThreshold(s string) (float64, error) {
+	s = strings.TrimSpace(s)
+	scale := 1.0
+	if strings.HasSuffix(s, "%") {
+		s, scale = strings.TrimSuffix(s, "%"), 100
+	}
+	p, err := strconv.ParseFloat(s, 64)
+	if err != nil || p/scale <= 0 || p/scale >= 1 {
+		return 0, fmt.Errorf("invalid threshold '%s' (use a probability such as 1%% or 0.001)", s)
+	}
+	return p / scale, nil
+}
+
+// FormatIDCount formats an ID count, switching to scientific notation for large values
+func FormatIDCount(n float64) string {
+	if n < 1e6 {
+		return strconv.FormatFloat(math.Floor(n), 'f', 0, 64)
+	}
+	return strconv.FormatFloat(n, 'g', 3, 64)
+}
+
+// FormatPercent formats a probability as a percentage with up to three significant digits
+func FormatPercent(p float64) string {
+	return strconv.FormatFloat(p*100, 'g', 3, 64) + "%"
+}
+
+// FormatSpan formats a number of seconds as a rough human duration
+func FormatSpan(seconds float64) string {
+	value, unit := seconds/secondsPerYear, "year"
+	switch {
+	case seconds < 1:
+		return fmt.Sprintf("%.3g ms", seconds*1000)
+	case seconds < 120:
+		value, unit = seconds, "second"
+	case seconds < 2*3600:
+		value, unit = seconds/60, "minute"
+	case seconds < 2*86400:
+		value, unit = seconds/3600, "hour"
+	case seconds < secondsPerYear:
+		value, unit = seconds/86400, "day"
+	}
+	formatted := strconv.FormatFloat(value, 'g', 3, 64)
+	if formatted != "1" {
+		unit += "s"
+	}
+	return formatted + " " + unit
+}
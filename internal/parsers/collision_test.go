@@ -0,0 +1,131 @@
+package parsers
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollisionProbability(t *testing.T) {
+	// 32 random bits reach even odds after about 77,000 IDs
+	if p := CollisionProbability(32, 77163); math.Abs(p-0.5) > 0.001 {
+		t.Errorf("Expected ~50%% for 77163 IDs of 32 bits, got %v", p)
+	}
+	if p := CollisionProbability(128, 1); p != 0 {
+		t.Errorf("Expected 0 for a single ID, got %v", p)
+	}
+	// Tiny probabilities must not round to zero
+	if p := CollisionProbability(122, 1e9); p <= 0 || math.Abs(p-9.4e-20)/9.4e-20 > 0.01 {
+		t.Errorf("Expected ~9.4e-20 for 1e9 UUIDv4s, got %v", p)
+	}
+}
+
+func TestCollisionCapacity(t *testing.T) {
+	// UUIDv4: 122 random bits reach a 50% chance after about 2.71e18 IDs
+	if n := CollisionCapacity(122, 0.5); math.Abs(n-2.71e18)/2.71e18 > 0.005 {
+		t.Errorf("Expected ~2.71e18 IDs, got %g", n)
+	}
+	// Capacity and probability are inverses of each other
+	n := CollisionCapacity(64, 0.01)
+	if p := CollisionProbability(64, n); math.Abs(p-0.01) > 1e-6 {
+		t.Errorf("Expected 1%% at capacity, got %v", p)
+	}
+}
+
+func TestEstimateCollision(t *testing.T) {
+	risk := EstimateCollision(126, 0, 0, 1000, secondsPerYear)
+	if risk.Threshold != DefaultCollisionThreshold {
+		t.Errorf("Expected default threshold, got %v", risk.Threshold)
+	}
+	if risk.Count != 1000*secondsPerYear || risk.Probability == nil {
+		t.Fatalf("Expected a count for one year at 1000/s, got %v", risk.Count)
+	}
+	if years := risk.SecondsToThreshold / secondsPerYear; math.Abs(years-4.14e7)/4.14e7 > 0.01 {
+		t.Errorf("Expected ~41 million years to 1%% risk, got %g", years)
+	}
+	if risk.Summary != "1% risk after 1.31e+18 IDs" {
+		t.Errorf("Unexpected summary %q", risk.Summary)
+	}
+}
+
+func TestParseCollisionInputs(t *testing.T) {
+	rates := map[string]float64{"1000/s": 1000, "60/min": 1, "3600/hours": 1, "5": 5, "2/ms": 2000}
+	for input, expected := range rates {
+		if got, err := ParseCollisionRate(input); err != nil || got != expected {
+			t.Errorf("ParseCollisionRate(%q) = %v, %v; expected %v", input, got, err, expected)
+		}
+	}
+	for _, input := range []string{"", "fast", "-1/s", "10/fortnight"} {
+		if _, err := ParseCollisionRate(input); err == nil {
+			t.Errorf("Expected error for rate %q", input)
+		}
+	}
+
+	periods := map[string]float64{"36h": 36 * 3600, "90d": 90 * 86400, "1y": secondsPerYear, "6 months": secondsPerYear / 2}
+	for input, expected := range periods {
+		if got, err := ParseCollisionPeriod(input); err != nil || math.Abs(got-expected) > 1e-6 {
+			t.Errorf("ParseCollisionPeriod(%q) = %v, %v; expected %v", input, got, err, expected)
+		}
+	}
+
+	for input, expected := range map[string]float64{"1%": 0.01, "0.001": 0.001, "50%": 0.5} {
+		if got, err := ParseCollisionThreshold(input); err != nil || math.Abs(got-expected) > 1e-12 {
+			t.Errorf("ParseCollisionThreshold(%q) = %v, %v; expected %v", input, got, err, expected)
+		}
+	}
+	for _, input := range []string{"0", "100%", "1.5", "x"} {
+		if _, err := ParseCollisionThreshold(input); err == nil {
+			t.Errorf("Expected error for threshold %q", input)
+		}
+	}
+}
+
+func TestEstimateTickCollision(t *testing.T) {
+	tick := TimestampTick{"millisecond", 0.001}
+	// 50,000 ULIDs a second are 50 per millisecond, sharing 80 random bits
+	risk := EstimateTickCollision(80, 0, 0, 50000, secondsPerYear, tick)
+	if risk.Probability == nil || math.Abs(*risk.Probability-3.26e-11)/3.26e-11 > 0.01 {
+		t.Fatalf("Expected ~3.26e-11 over a year, got %v", risk.Probability)
+	}
+	if risk.Tick != "millisecond" || risk.Summary != "1% risk after 1.56e+11 IDs per millisecond" {
+		t.Errorf("Unexpected per-tick summary %q", risk.Summary)
+	}
+	// The time to the threshold is where the combined probability reaches it
+	check := EstimateTickCollision(80, 0.01, 0, 50000, risk.SecondsToThreshold, tick)
+	if math.Abs(*check.Probability-0.01) > 1e-9 {
+		t.Errorf("Expected 1%% after %g seconds, got %v", risk.SecondsToThreshold, *check.Probability)
+	}
+	// A count is spread over the ticks the rate needs for it
+	counted := EstimateTickCollision(80, 0, 50000*secondsPerYear, 50000, 0, tick)
+	if math.Abs(*counted.Probability-*risk.Probability)/(*risk.Probability) > 1e-9 {
+		t.Errorf("Expected the same risk for a count as for its period, got %v", *counted.Probability)
+	}
+}
+
+func TestTimestampTickOf(t *testing.T) {
+	tests := map[string]string{
+		"01ARZ3NDEKTSV4RRFFQ69G5FAV":           "millisecond",
+		"507f1f77bcf86cd799439011":             "second",
+		"01941f29-7c00-7aaa-aaaa-aaaaaaaaaaaa": "millisecond",
+		"c6c9b9c0-5b2e-11ee-8c99-0242ac120002": "100 ns",
+	}
+	for input, label := range tests {
+		results := ParseID(input, "")
+		if len(results) == 0 {
+			t.Errorf("Failed to parse %s", input)
+			continue
+		}
+		tick, ok := TimestampTickOf(results[0])
+		if !ok || tick.Label != label {
+			t.Errorf("%s: expected a %s tick, got %v", input, label, tick)
+		}
+		// The card's capacity is per tick for time-based IDs
+		if c := results[0].Collision; c != nil && c.Tick != label {
+			t.Errorf("%s: expected the collision row per %s, got %q", input, label, c.Summary)
+		}
+	}
+
+	results := ParseID("550e8400-e29b-41d4-a716-446655440000", "")
+	if _, ok := TimestampTickOf(results[0]); ok || results[0].Collision.Tick != "" {
+		t.Error("Expected a random UUID to have no tick")
+	}
+}
@@ -0,0 +1,213 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Crockford Base32 alphabet, followed by the five extra mod-37 check symbols
+const (
+	crockfordAlphabet     = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	crockfordCheckSymbols = crockfordAlphabet + "*~$=U"
+)
+
+// crockfordValues maps every accepted input character to its value, including
+// lowercase letters and the I/L -> 1 and O -> 0 aliases; -1 marks invalid characters
+var crockfordValues = func() [256]int {
+	var values [256]int
+	for i := range values {
+		values[i] = -1
+	}
+	for i := 0; i < len(crockfordCheckSymbols); i++ {
+		c := crockfordCheckSymbols[i]
+		values[c] = i
+		if c >= 'A' && c <= 'Z' {
+			values[c+'a'-'A'] = i
+		}
+	}
+	for _, c := range "IiLl" {
+		values[c] = 1
+	}
+	values['O'], values['o'] = 0, 0
+	return values
+}()
+
+// crockfordNormalize drops hyphens, upper-cases and resolves the I, L and O aliases.
+// It reports whether the input differed from its canonical form.
+func crockfordNormalize(input string) (string, bool, error) {
+	var sb strings.Builder
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if c == '-' {
+			continue
+		}
+		v := crockfordValues[c]
+		if v < 0 || v >= 32 {
+			return "", false, fmt.Errorf("invalid Crockford Base32 character %q", c)
+		}
+		sb.WriteByte(crockfordAlphabet[v])
+	}
+	normalized := sb.String()
+	return normalized, normalized != input, nil
+}
+
+// crockfordDecodeInt decodes a canonical or aliased Crockford Base32 string to an integer
+func crockfordDecodeInt(input string) (*big.Int, error) {
+	normalized, _, err := crockfordNormalize(input)
+	if err != nil {
+		return nil, err
+	}
+	if normalized == "" {
+		return nil, fmt.Errorf("empty Crockford Base32 string")
+	}
+	n := new(big.Int)
+	for i := 0; i < len(normalized); i++ {
+		n.Lsh(n, 5)
+		n.Or(n, big.NewInt(int64(crockfordValues[normalized[i]])))
+	}
+	return n, nil
+}
+
+// crockfordDecodeBytes decodes a Crockford Base32 string into exactly size bytes,
+// failing if the value does not fit (e.g. a ULID whose first character exceeds 7)
+func crockfordDecodeBytes(input string, size int) ([]byte, error) {
+	n, err := crockfordDecodeInt(input)
+	if err != nil {
+		return nil, err
+	}
+	if n.BitLen() > size*8 {
+		return nil, fmt.Errorf("Crockford Base32 value overflows %d bytes", size)
+	}
+	return n.FillBytes(make([]byte, size)), nil
+}
+
+// crockfordEncodeInt encodes n in Crockford Base32, left-padded with zeros to width characters
+func crockfordEncodeInt(n *big.Int, width int) string {
+	var digits []byte
+	v := new(big.Int).Set(n)
+	mod := new(big.Int)
+	for v.Sign() > 0 {
+		v.DivMod(v, big.NewInt(32), mod)
+		digits = append(digits, crockfordAlphabet[mod.Int64()])
+	}
+	for len(digits) < width {
+		digits = append(digits, '0')
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// crockfordCheckSymbol returns the mod-37 check symbol for a value
+func crockfordCheckSymbol(n *big.Int) byte {
+	mod := new(big.Int).Mod(n, big.NewInt(37))
+	return crockfordCheckSymbols[mod.Int64()]
+}
+
+// crockfordSplitCheck validates a trailing check symbol and returns the body and its value
+func crockfordSplitCheck(input string) (string, *big.Int, error) {
+	if len(input) < 2 {
+		return "", nil, fmt.Errorf("too short for a check symbol")
+	}
+	body, check := input[:len(input)-1], input[len(input)-1]
+	checkValue := crockfordValues[check]
+	if checkValue < 0 {
+		return "", nil, fmt.Errorf("invalid check symbol %q", check)
+	}
+	n, err := crockfordDecodeInt(body)
+	if err != nil {
+		return "", nil, err
+	}
+	if expected := crockfordCheckSymbol(n); crockfordCheckSymbols[checkValue] != expected {
+		return "", nil, fmt.Errorf("check symbol %c does not match, expected %c", check, expected)
+	}
+	return body, n, nil
+}
+
+// CrockfordParser handles generic Crockford Base32 strings that carry a mod-37 check symbol
+type CrockfordParser struct{}
+
+func (p *CrockfordParser) Name() string {
+	return "Crockford"
+}
+
+func (p *CrockfordParser) CanParse(input string) bool {
+	// Without a check symbol any alphanumeric string would qualify, so
+	// auto-detection only claims inputs whose check symbol validates
+	if len(input) < 8 || len(input) > 64 {
+		return false
+	}
+	// All-digit strings are left to the timestamp and Snowflake parsers
+	if isDigits(input) {
+		return false
+	}
+	_, _, err := crockfordSplitCheck(input)
+	return err == nil
+}
+
+func (p *CrockfordParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty Crockford Base32 string")
+	}
+
+	body, n, err := crockfordSplitCheck(input)
+	checkStatus, check := "none", ""
+	if err == nil {
+		check = strings.ToUpper(input[len(input)-1:])
+		checkStatus = fmt.Sprintf("valid (%s, mod 37)", check)
+	} else if strings.ContainsAny(input[len(input)-1:], "*~$=Uu") {
+		// These symbols only ever appear as check characters
+		return nil, fmt.Errorf("invalid Crockford Base32 check symbol: %v", err)
+	} else {
+		body = input
+		n, err = crockfordDecodeInt(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid Crockford Base32: %v", err)
+		}
+	}
+
+	normalized, aliased, _ := crockfordNormalize(body)
+	raw := n.Bytes()
+	if len(raw) == 0 {
+		raw = []byte{0}
+	}
+	integer := n.String()
+	entropy := len(normalized) * 5
+
+	extra := map[string]string{
+		"encoding":     "Crockford Base32",
+		"check_symbol": checkStatus,
+		"characters":   fmt.Sprintf("%d", len(normalized)),
+		"decoded_size": fmt.Sprintf("%d bytes", len(raw)),
+	}
+	if aliased {
+		extra["normalized"] = normalized
+		extra["decoding_rules"] = "hyphens ignored, case folded, I/L read as 1 and O as 0"
+	}
+
+	return &types.IDInfo{
+		IDType:   "Crockford Base32",
+		Standard: normalized + check,
+		Integer:  &integer,
+		Size:     len(normalized) * 5,
+		Entropy:  &entropy,
+		Hex:      fmt.Sprintf("%x", raw),
+		Binary:   raw,
+		Extra:    extra,
+	}, nil
+}
+
+func (p *CrockfordParser) Generate() (string, error) {
+	raw := make([]byte, 10)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	n := new(big.Int).SetBytes(raw)
+	return crockfordEncodeInt(n, 16) + string(crockfordCheckSymbol(n)), nil
+}
@@ -0,0 +1,123 @@
+package parsers
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestCrockfordCodec(t *testing.T) {
+	n := big.NewInt(1234)
+	if encoded := crockfordEncodeInt(n, 0); encoded != "16J" {
+		t.Errorf("Expected 16J, got %s", encoded)
+	}
+	if check := crockfordCheckSymbol(n); check != 'D' {
+		t.Errorf("Expected check symbol D, got %c", check)
+	}
+
+	// Decoding is case-insensitive, ignores hyphens and reads I/L as 1 and O as 0
+	aliases := []string{"16J", "16j", "l6J", "I6-J", "016J", "o16j"}
+	for _, input := range aliases {
+		value, err := crockfordDecodeInt(input)
+		if err != nil || value.Int64() != 1234 {
+			t.Errorf("Decode(%s) = %v, %v; expected 1234", input, value, err)
+		}
+	}
+
+	for _, input := range []string{"16U", "16*", "16!", ""} {
+		if _, err := crockfordDecodeInt(input); err == nil {
+			t.Errorf("Expected error decoding %q", input)
+		}
+	}
+
+	if _, err := crockfordDecodeBytes("8ZZZZZZZZZZZZZZZZZZZZZZZZZ", 16); err == nil {
+		t.Error("Expected overflow error for a 26-character value above 128 bits")
+	}
+
+	body, value, err := crockfordSplitCheck("16JD")
+	if err != nil || body != "16J" || value.Int64() != 1234 {
+		t.Errorf("Expected valid check symbol, got %s %v %v", body, value, err)
+	}
+	if _, _, err := crockfordSplitCheck("16JE"); err == nil {
+		t.Error("Expected check symbol mismatch")
+	}
+
+	// Values that are multiples of 37 plus 32..36 use the extra check symbols
+	if check := crockfordCheckSymbol(big.NewInt(36)); check != 'U' {
+		t.Errorf("Expected check symbol U, got %c", check)
+	}
+}
+
+func TestCrockfordParser(t *testing.T) {
+	parser := &CrockfordParser{}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated value has no valid check symbol: %s", generated)
+	}
+
+	info, err := parser.Parse("0000-016j-d")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if *info.Integer != "1234" || info.Extra["check_symbol"] != "valid (D, mod 37)" {
+		t.Errorf("Unexpected decode: %s / %s", *info.Integer, info.Extra["check_symbol"])
+	}
+	if info.Standard != "0000016JD" {
+		t.Errorf("Expected canonical form 0000016JD, got %s", info.Standard)
+	}
+
+	// Forced parsing also accepts values without a check symbol
+	info, err = parser.Parse("16J")
+	if err != nil || info.Extra["check_symbol"] != "none" {
+		t.Errorf("Expected decode without check symbol, got %v", err)
+	}
+	if _, err := parser.Parse("16J*"); err == nil {
+		t.Error("Expected error for a mismatched * check symbol")
+	}
+
+	if parser.CanParse("1234567890123") {
+		t.Error("Expected to leave all-digit inputs to the numeric parsers")
+	}
+	if parser.CanParse("HELLOWORLD") || parser.CanParse("16JD") {
+		t.Error("Expected to reject inputs without a valid check symbol or too short")
+	}
+}
+
+func TestULIDParser_CrockfordAliases(t *testing.T) {
+	parser := &ULIDParser{}
+
+	canonical, err := parser.Parse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	for _, input := range []string{"01arz3ndektsv4rrffq69g5fav", "OIARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3ND-EKTSV4RR-FFQ69G5FAV"} {
+		info, err := parser.Parse(input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", input, err)
+			continue
+		}
+		if info.Hex != canonical.Hex || info.Standard != canonical.Standard {
+			t.Errorf("Parse(%s) = %s, expected %s", input, info.Standard, canonical.Standard)
+		}
+	}
+
+	if _, err := parser.Parse("81ARZ3NDEKTSV4RRFFQ69G5FAV"); err == nil {
+		t.Error("Expected overflow error for a ULID starting with 8")
+	}
+}
+
+func TestTypeIDParser_DecodesUUIDv7(t *testing.T) {
+	info, err := (&TypeIDParser{}).Parse("user_01h455vb4pex5vsknk084sn02q")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Hex != "01890a5dac96774bbcceb302099a8057" {
+		t.Errorf("Unexpected UUID bytes: %s", info.Hex)
+	}
+	if info.DateTime == nil || info.DateTime.UnixMilli() != 0x01890a5dac96 {
+		t.Errorf("Expected UUIDv7 timestamp, got %v", info.DateTime)
+	}
+}
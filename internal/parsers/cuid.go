@@ -3,22 +3,33 @@ package parsers
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"time"
 
 	"github.com/zcyc/idinfo/internal/types"
 
 	"github.com/nrednav/cuid2"
 )
 
+// CUIDParser handles CUID2 and the legacy CUID v1 layout. CUID v1 is checked first because
+// its shape is far more specific: every v1 value is also accepted by cuid2.IsCuid.
 type CUIDParser struct{}
 
 // CUID2 uses lowercase letters and digits with variable length
 var cuid2Regex = regexp.MustCompile(`^[a-z0-9]+$`)
 
+// CUID v1 is 'c' + 8 timestamp + 4 counter + 4 fingerprint + 8 random characters, all base36
+var cuid1Regex = regexp.MustCompile(`^c[a-z0-9]{24}$`)
+
 func (p *CUIDParser) Name() string {
 	return "CUID"
 }
 
 func (p *CUIDParser) CanParse(input string) bool {
+	if isCUIDv1(input) {
+		return true
+	}
+
 	// CUID2 typically generates IDs between 4-32 characters
 	if len(input) < 4 || len(input) > 32 {
 		return false
@@ -37,53 +48,28 @@ func (p *CUIDParser) Parse(input string) (*types.IDInfo, error) {
 	if !p.CanParse(input) {
 		return nil, fmt.Errorf("invalid CUID format")
 	}
+	if isCUIDv1(input) {
+		return parseCUIDv1(input)
+	}
 
 	info := &types.IDInfo{
 		IDType:   "CUID v2 (Collision-resistant Unique Identifier)",
+		Version:  "2",
 		Standard: input,
 		Size:     len(input) * 6, // ~5.2 bits per character in practice
 		Extra:    make(map[string]string),
 	}
 
-	// Convert to hex representation
-	hexStr := ""
-	for _, char := range input {
-		if char >= '0' && char <= '9' {
-			hexStr += fmt.Sprintf("%x", char-'0')
-		} else if char >= 'a' && char <= 'z' {
-			hexStr += fmt.Sprintf("%x", char-'a'+10)
-		}
-	}
-	info.Hex = hexStr
-
-	// Convert hex to binary - only if valid hex length
-	if len(hexStr)%2 == 0 {
-		if hexBytes := make([]byte, len(hexStr)/2); len(hexBytes) > 0 {
-			for i := 0; i < len(hexStr); i += 2 {
-				var byteVal byte
-				if hexStr[i] >= '0' && hexStr[i] <= '9' {
-					byteVal = (hexStr[i] - '0') << 4
-				} else {
-					byteVal = (hexStr[i] - 'a' + 10) << 4
-				}
-				if hexStr[i+1] >= '0' && hexStr[i+1] <= '9' {
-					byteVal |= hexStr[i+1] - '0'
-				} else {
-					byteVal |= hexStr[i+1] - 'a' + 10
-				}
-				hexBytes[i/2] = byteVal
-			}
-			info.Binary = hexBytes
-		}
-	}
-
 	// CUID2 has high entropy due to cryptographically secure random generation
 	entropy := int(float64(len(input)) * 5.2) // More accurate bits per character
 	info.Entropy = &entropy
 
-	// Add CUID2-specific information
+	// Add CUID2-specific information. The body is a truncated SHA3 hash of the time, a counter,
+	// a fingerprint and random salt, so none of them can be recovered and there are no raw bytes.
 	info.Extra["version"] = "2"
 	info.Extra["encoding"] = "Base36 (lowercase)"
+	info.Extra["structure"] = "opaque: random letter + base36 of a SHA3-512 hash"
+	info.Extra["decodable"] = "No (time, counter and fingerprint are hashed)"
 	info.Extra["collision_resistant"] = "Yes"
 	info.Extra["cryptographically_secure"] = "Yes"
 	info.Extra["url_safe"] = "Yes"
@@ -98,3 +84,69 @@ func (p *CUIDParser) Generate() (string, error) {
 	// Use the official CUID2 library to generate an ID
 	return cuid2.Generate(), nil
 }
+
+// isCUIDv1 reports whether input has the CUID v1 layout with a plausible creation time.
+// A 25-character CUID2 starting with 'c' only passes when its next 8 characters happen to
+// read as a time between 2010 and now.
+func isCUIDv1(input string) bool {
+	if !cuid1Regex.MatchString(input) {
+		return false
+	}
+	ms, err := strconv.ParseInt(input[1:9], 36, 64)
+	if err != nil {
+		return false
+	}
+	t := time.UnixMilli(ms)
+	minTime := time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC)
+	return !t.Before(minTime) && !t.After(time.Now().Add(24*time.Hour))
+}
+
+// parseCUIDv1 splits a legacy CUID into its timestamp, counter, fingerprint and random blocks
+func parseCUIDv1(input string) (*types.IDInfo, error) {
+	timestampMs, err := strconv.ParseInt(input[1:9], 36, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CUID v1 timestamp: %v", err)
+	}
+	counter, err := strconv.ParseInt(input[9:13], 36, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CUID v1 counter: %v", err)
+	}
+	fingerprint := input[13:17]
+	random := input[17:25]
+
+	// Node fingerprints are the process ID and a hostname checksum, each as 2 base36 digits
+	pid, _ := strconv.ParseInt(fingerprint[:2], 36, 64)
+	host, _ := strconv.ParseInt(fingerprint[2:], 36, 64)
+
+	t := time.UnixMilli(timestampMs)
+	timestampStr := fmt.Sprintf("%.3f", float64(timestampMs)/1000)
+
+	// Two blocks of 4 base36 digits from Math.random: 8 * log2(36) bits
+	entropy := 41
+
+	return &types.IDInfo{
+		IDType:    "CUID v1 (Collision-resistant Unique Identifier)",
+		Version:   "1",
+		Standard:  input,
+		Size:      len(input) * 6,
+		Entropy:   &entropy,
+		DateTime:  &t,
+		Timestamp: &timestampStr,
+		Sequence:  &counter,
+		Node1:     &fingerprint,
+		Extra: map[string]string{
+			"version":             "1 (deprecated, superseded by CUID2)",
+			"encoding":            "Base36 (lowercase)",
+			"structure":           "c + timestamp(8) + counter(4) + fingerprint(4) + random(8)",
+			"timestamp_base36":    input[1:9],
+			"timestamp_precision": "millisecond",
+			"counter":             fmt.Sprintf("%d (base36 %s, wraps at 1679616)", counter, input[9:13]),
+			"fingerprint":         fingerprint,
+			"fingerprint_pid":     fmt.Sprintf("%s (process ID mod 1296 = %d)", fingerprint[:2], pid),
+			"fingerprint_host":    fmt.Sprintf("%s (hostname checksum mod 1296 = %d)", fingerprint[2:], host),
+			"random":              random,
+			"random_source":       "Math.random (not cryptographically secure)",
+			"length":              "25",
+		},
+	}, nil
+}
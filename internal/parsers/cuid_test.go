@@ -120,9 +120,9 @@ func TestCUIDParser_Parse(t *testing.T) {
 		t.Errorf("Expected length to be set, got empty")
 	}
 	
-	// Check hex representation exists
-	if len(info.Hex) == 0 {
-		t.Error("Expected Hex representation to be set")
+	// CUID2 is an opaque hash, so there are no raw bytes to show
+	if info.Hex != "" || info.Binary != nil {
+		t.Errorf("Expected no Hex/Binary for CUID2, got %q / %v", info.Hex, info.Binary)
 	}
 	
 	// Test invalid input
@@ -276,4 +276,66 @@ func TestCUIDParser_EdgeCases(t *testing.T) {
 			}
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestCUIDParser_ParseV1(t *testing.T) {
+	parser := &CUIDParser{}
+
+	// Example from the original cuid README
+	input := "ch72gsb320000udocl363eofy"
+	if !parser.CanParse(input) {
+		t.Fatalf("Expected to parse CUID v1: %s", input)
+	}
+	info, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Failed to parse CUID v1: %v", err)
+	}
+	if info.Version != "1" {
+		t.Errorf("Expected version 1, got %s", info.Version)
+	}
+	if info.DateTime == nil || info.DateTime.UnixMilli() != 1347577392926 {
+		t.Errorf("Expected timestamp 1347577392926, got %v", info.DateTime)
+	}
+	if info.Sequence == nil || *info.Sequence != 0 {
+		t.Errorf("Expected counter 0, got %v", info.Sequence)
+	}
+	if info.Node1 == nil || *info.Node1 != "udoc" {
+		t.Errorf("Expected fingerprint udoc, got %v", info.Node1)
+	}
+	if info.Extra["random"] != "l363eofy" {
+		t.Errorf("Expected random block l363eofy, got %s", info.Extra["random"])
+	}
+
+	info, err = parser.Parse("cjld2cjxh0001qzrmn831i7rn")
+	if err != nil {
+		t.Fatalf("Failed to parse CUID v1: %v", err)
+	}
+	if info.Sequence == nil || *info.Sequence != 1 {
+		t.Errorf("Expected counter 1, got %v", info.Sequence)
+	}
+}
+
+func TestCUIDParser_V1Ranking(t *testing.T) {
+	parser := &CUIDParser{}
+
+	// Default CUID2 values are 24 characters and never take the v1 path
+	for i := 0; i < 20; i++ {
+		id := cuid2.Generate()
+		info, err := parser.Parse(id)
+		if err != nil {
+			t.Fatalf("Failed to parse CUID2 %s: %v", id, err)
+		}
+		if info.Version != "2" {
+			t.Errorf("Expected %s to be CUID2, got version %s", id, info.Version)
+		}
+	}
+
+	// A 'c'-prefixed 25-character value whose timestamp is implausible stays CUID2
+	info, err := parser.Parse("czzzzzzzz0000udocl363eofy")
+	if err != nil {
+		t.Fatalf("Failed to parse: %v", err)
+	}
+	if info.Version != "2" {
+		t.Errorf("Expected version 2 for an implausible v1 timestamp, got %s", info.Version)
+	}
+}
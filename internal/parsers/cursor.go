@@ -0,0 +1,296 @@
+package parsers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Cursors rarely nest more than a few layers; the limit stops runaway recursion on crafted input
+const cursorMaxDepth = 16
+
+var percentEscapeRegex = regexp.MustCompile(`%[0-9A-Fa-f]{2}`)
+
+// DecodeCursor peels the wrapping layers of a pagination cursor or opaque token (URL escaping,
+// base64/base64url, gzip, zlib) and decodes the JSON, msgpack or protobuf payload underneath.
+// Every leaf string, number and bytes value is run through the registry.
+func (r *Registry) DecodeCursor(input string) (*types.CursorNode, error) {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil, fmt.Errorf("empty cursor")
+	}
+	node := r.peelCursor("", []byte(input), 0)
+	if len(node.Layers) == 0 && len(node.Children) == 0 {
+		return nil, fmt.Errorf("no base64, compression, JSON, msgpack or protobuf layer found")
+	}
+	return node, nil
+}
+
+// peelCursor unwraps encodings until it reaches structured data or a leaf value
+func (r *Registry) peelCursor(key string, data []byte, depth int) *types.CursorNode {
+	var layers []string
+	for ; depth < cursorMaxDepth; depth++ {
+		next, layer := unwrapCursorLayer(data)
+		if layer == "" {
+			break
+		}
+		layers = append(layers, layer)
+		data = next
+	}
+
+	node := r.structuredCursorNode(key, data, depth)
+	if node == nil && depth < cursorMaxDepth && worthBase64Peel(data) {
+		if decoded, alphabet, err := decodeBase64(string(data)); err == nil && len(decoded) >= 2 {
+			inner := r.peelCursor(key, decoded, depth+1)
+			// Only keep the base64 layer when it led somewhere; otherwise the string is the value
+			if len(inner.Children) > 0 || len(inner.Layers) > 0 {
+				layer := "base64"
+				if alphabet == "Base64URL" {
+					layer = "base64url"
+				}
+				inner.Layers = append(append(layers, layer), inner.Layers...)
+				return inner
+			}
+		}
+	}
+	if node == nil {
+		node = r.cursorLeaf(key, data)
+	}
+	node.Layers = append(layers, node.Layers...)
+	return node
+}
+
+// unwrapCursorLayer removes one compression or escaping layer, returning the layer name or ""
+func unwrapCursorLayer(data []byte) ([]byte, string) {
+	switch {
+	case len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, ""
+		}
+		out, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, ""
+		}
+		return out, "gzip"
+	case len(data) > 2 && data[0]&0x0f == 8 && (uint16(data[0])<<8|uint16(data[1]))%31 == 0:
+		reader, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, ""
+		}
+		out, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, ""
+		}
+		return out, "zlib"
+	case isCursorText(data) && percentEscapeRegex.Match(data):
+		out, err := url.QueryUnescape(string(data))
+		if err != nil || out == string(data) {
+			return nil, ""
+		}
+		return []byte(out), "url-encoding"
+	}
+	return nil, ""
+}
+
+// structuredCursorNode decodes JSON, msgpack or protobuf, returning nil for anything else
+func (r *Registry) structuredCursorNode(key string, data []byte, depth int) *types.CursorNode {
+	if isCursorText(data) {
+		trimmed := bytes.TrimSpace(data)
+		if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+			decoder := json.NewDecoder(bytes.NewReader(trimmed))
+			decoder.UseNumber()
+			var value interface{}
+			if err := decoder.Decode(&value); err == nil {
+				node := r.cursorValueNode(key, value, depth)
+				node.Layers = append([]string{"json"}, node.Layers...)
+				return node
+			}
+		}
+		return nil
+	}
+
+	if value, n, err := decodeMsgpack(data); err == nil && n == len(data) {
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			node := r.cursorValueNode(key, value, depth)
+			node.Layers = append([]string{"msgpack"}, node.Layers...)
+			return node
+		}
+	}
+
+	if fields, ok := decodeProtobuf(data); ok {
+		node := &types.CursorNode{Key: key, Kind: "message", Layers: []string{"protobuf"}}
+		for _, field := range fields {
+			name := fmt.Sprintf("#%d", field.number)
+			switch v := field.value.(type) {
+			case []byte:
+				node.Children = append(node.Children, r.peelCursor(name, v, depth+1))
+			case uint64:
+				child := &types.CursorNode{Key: name, Kind: field.wireType, Value: fmt.Sprintf("%d", v)}
+				child.IDs = r.cursorIDs(name, child.Value)
+				node.Children = append(node.Children, child)
+			}
+		}
+		return node
+	}
+	return nil
+}
+
+// cursorValueNode converts a decoded JSON or msgpack value into a tree
+func (r *Registry) cursorValueNode(key string, value interface{}, depth int) *types.CursorNode {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		node := &types.CursorNode{Key: key, Kind: "object"}
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			node.Children = append(node.Children, r.cursorValueNode(k, v[k], depth+1))
+		}
+		return node
+	case []interface{}:
+		node := &types.CursorNode{Key: key, Kind: "array"}
+		for i, item := range v {
+			node.Children = append(node.Children, r.cursorValueNode(fmt.Sprintf("[%d]", i), item, depth+1))
+		}
+		return node
+	case string:
+		// Strings may wrap further layers, such as a base64 cursor inside a JSON envelope
+		return r.peelCursor(key, []byte(v), depth+1)
+	case []byte:
+		return r.peelCursor(key, v, depth+1)
+	case nil:
+		return &types.CursorNode{Key: key, Kind: "null"}
+	case bool:
+		return &types.CursorNode{Key: key, Kind: "bool", Value: fmt.Sprintf("%t", v)}
+	default:
+		node := &types.CursorNode{Key: key, Kind: "number", Value: fmt.Sprintf("%v", v)}
+		node.IDs = r.cursorIDs(key, node.Value)
+		return node
+	}
+}
+
+// cursorLeaf describes a string or bytes value that could not be unwrapped further
+func (r *Registry) cursorLeaf(key string, data []byte) *types.CursorNode {
+	if isCursorText(data) {
+		node := &types.CursorNode{Key: key, Kind: "string", Value: string(data)}
+		node.IDs = r.cursorIDs(key, node.Value)
+		if len(node.IDs) > 0 && node.IDs[0].Info.Secret {
+			// API keys and card numbers stay masked in the tree as well
+			node.Value = node.IDs[0].Value
+		}
+		return node
+	}
+	return &types.CursorNode{Key: key, Kind: "bytes", Value: hex.EncodeToString(data), IDs: r.embeddedIDs(data)}
+}
+
+// cursorIDs runs the registry on a leaf value and keeps the best match. Like JWT claims, short
+// values are skipped, and so are values without a digit: words are almost always misread as NanoIDs.
+func (r *Registry) cursorIDs(field, value string) []types.NestedID {
+	if len(value) < jwtMinNestedLength || !strings.ContainsAny(value, "0123456789") {
+		return nil
+	}
+	results := r.ParseID(value, "")
+	if len(results) == 0 {
+		return nil
+	}
+	info := results[0]
+	if info.Secret {
+		value = info.Standard
+	}
+	return []types.NestedID{{Field: field, Value: value, Info: info}}
+}
+
+// worthBase64Peel reports whether a value might be base64 wrapping more data. Numbers and
+// hex strings also decode as base64 but are almost always values in their own right.
+func worthBase64Peel(data []byte) bool {
+	if len(data) < 4 || !isCursorText(data) || !base64Regex.Match(data) {
+		return false
+	}
+	s := string(data)
+	return !isDigits(s) && !hashHexRegex.MatchString(s)
+}
+
+// isCursorText reports whether data is UTF-8 text without control characters
+func isCursorText(data []byte) bool {
+	if len(data) == 0 || !utf8.Valid(data) {
+		return false
+	}
+	for _, c := range string(data) {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' || c == 0x7f || c == utf8.RuneError {
+			return false
+		}
+	}
+	return true
+}
+
+// protoField is one field of a protobuf message decoded without a schema
+type protoField struct {
+	number   uint64
+	wireType string
+	value    interface{} // uint64 for varint and fixed fields, []byte for length-delimited ones
+}
+
+// decodeProtobuf decodes the protobuf wire format without a schema. It fails unless the whole
+// input parses as fields with valid numbers and wire types; deprecated groups are not supported.
+func decodeProtobuf(data []byte) ([]protoField, bool) {
+	var fields []protoField
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return nil, false
+		}
+		data = data[n:]
+		number := tag >> 3
+		if number == 0 || number > 1<<29-1 {
+			return nil, false
+		}
+
+		switch tag & 7 {
+		case 0:
+			v, n := binary.Uvarint(data)
+			if n <= 0 {
+				return nil, false
+			}
+			fields = append(fields, protoField{number, "varint", v})
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return nil, false
+			}
+			fields = append(fields, protoField{number, "fixed64", binary.LittleEndian.Uint64(data)})
+			data = data[8:]
+		case 2:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return nil, false
+			}
+			fields = append(fields, protoField{number, "bytes", data[n : n+int(length)]})
+			data = data[n+int(length):]
+		case 5:
+			if len(data) < 4 {
+				return nil, false
+			}
+			fields = append(fields, protoField{number, "fixed32", uint64(binary.LittleEndian.Uint32(data))})
+			data = data[4:]
+		default:
+			return nil, false
+		}
+	}
+	return fields, len(fields) > 0
+}
@@ -0,0 +1,179 @@
+package parsers
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// DiffIDs aligns the fields and Extra details of two or more parsed IDs and relates every ID to
+// the first one: time delta, whether the node components match, counter distance and which bits
+// of the binary form differ
+func DiffIDs(infos []*types.IDInfo) (*types.IDDiff, error) {
+	if len(infos) < 2 {
+		return nil, fmt.Errorf("need at least 2 IDs to compare, got %d", len(infos))
+	}
+
+	diff := &types.IDDiff{IDs: infos}
+	field := func(name string, value func(*types.IDInfo) string) {
+		row := types.FieldDiff{Name: name, Same: true}
+		for _, info := range infos {
+			row.Values = append(row.Values, value(info))
+		}
+		for _, v := range row.Values[1:] {
+			if v != row.Values[0] {
+				row.Same = false
+			}
+		}
+		// Fields no ID has are left out
+		if strings.Join(row.Values, "") != "" {
+			diff.Fields = append(diff.Fields, row)
+		}
+	}
+
+	field("id_type", func(i *types.IDInfo) string { return i.IDType })
+	field("version", func(i *types.IDInfo) string { return i.Version })
+	field("standard", func(i *types.IDInfo) string { return i.Standard })
+	field("integer", func(i *types.IDInfo) string { return deref(i.Integer) })
+	field("size", func(i *types.IDInfo) string { return fmt.Sprintf("%d bits", i.Size) })
+	field("entropy", func(i *types.IDInfo) string {
+		if i.Entropy == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d bits", *i.Entropy)
+	})
+	field("datetime", func(i *types.IDInfo) string {
+		if i.DateTime == nil {
+			return ""
+		}
+		return i.DateTime.UTC().Format(time.RFC3339Nano)
+	})
+	field("node1", func(i *types.IDInfo) string { return deref(i.Node1) })
+	field("node2", func(i *types.IDInfo) string { return deref(i.Node2) })
+	field("sequence", func(i *types.IDInfo) string {
+		if i.Sequence == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d", *i.Sequence)
+	})
+	field("hex", func(i *types.IDInfo) string { return i.Hex })
+
+	keys := map[string]bool{}
+	for _, info := range infos {
+		for key := range info.Extra {
+			keys[key] = true
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+	for _, key := range sorted {
+		field("extra."+key, func(i *types.IDInfo) string { return i.Extra[key] })
+	}
+
+	base := infos[0]
+	for i, info := range infos[1:] {
+		diff.Relations = append(diff.Relations, relateIDs(i+1, base, info))
+	}
+	return diff, nil
+}
+
+// relateIDs compares one ID with the baseline
+func relateIDs(index int, base, other *types.IDInfo) types.IDRelation {
+	rel := types.IDRelation{Index: index}
+	if base.DateTime != nil && other.DateTime != nil {
+		ms := float64(other.DateTime.Sub(*base.DateTime).Nanoseconds()) / 1e6
+		rel.TimeDeltaMs = &ms
+	}
+	if base.Node1 != nil && other.Node1 != nil {
+		same := *base.Node1 == *other.Node1
+		rel.SameNode1 = &same
+	}
+	if base.Node2 != nil && other.Node2 != nil {
+		same := *base.Node2 == *other.Node2
+		rel.SameNode2 = &same
+	}
+	if base.Sequence != nil && other.Sequence != nil {
+		distance := *other.Sequence - *base.Sequence
+		rel.CounterDistance = &distance
+	}
+
+	if len(base.Binary) > 0 && len(base.Binary) == len(other.Binary) {
+		mask := make([]byte, len(base.Binary))
+		for i := range mask {
+			mask[i] = base.Binary[i] ^ other.Binary[i]
+			rel.DifferingBits += bits.OnesCount8(mask[i])
+		}
+		rel.BitMask = mask
+	}
+	rel.Summary = relationSummary(base, other, rel)
+	return rel
+}
+
+// relationSummary states in one sentence how an ID relates to the baseline
+func relationSummary(base, other *types.IDInfo, rel types.IDRelation) string {
+	if base.Standard == other.Standard {
+		return "identical"
+	}
+
+	var parts []string
+	if base.IDType != other.IDType {
+		parts = append(parts, fmt.Sprintf("different formats (%s vs %s)", base.IDType, other.IDType))
+	}
+	if rel.TimeDeltaMs != nil {
+		delta := time.Duration(*rel.TimeDeltaMs * float64(time.Millisecond))
+		switch {
+		case delta > 0:
+			parts = append(parts, fmt.Sprintf("created %s later", delta))
+		case delta < 0:
+			parts = append(parts, fmt.Sprintf("created %s earlier", -delta))
+		default:
+			parts = append(parts, "created at the same time")
+		}
+	}
+	// Only the timestamps of different formats are comparable
+	if base.IDType != other.IDType {
+		return strings.Join(parts, ", ")
+	}
+	sameNodes := rel.SameNode1 != nil || rel.SameNode2 != nil
+	for _, same := range []*bool{rel.SameNode1, rel.SameNode2} {
+		if same != nil && !*same {
+			sameNodes = false
+		}
+	}
+	switch {
+	case sameNodes:
+		parts = append(parts, "same generator")
+	case rel.SameNode1 != nil || rel.SameNode2 != nil:
+		parts = append(parts, "different generator")
+	}
+	// Counters of different generators are unrelated
+	differentNodes := !sameNodes && (rel.SameNode1 != nil || rel.SameNode2 != nil)
+	if rel.CounterDistance != nil && *rel.CounterDistance != 0 && !differentNodes {
+		parts = append(parts, fmt.Sprintf("counter %+d", *rel.CounterDistance))
+	}
+	if sameNodes && rel.TimeDeltaMs != nil && *rel.TimeDeltaMs == 0 && rel.CounterDistance != nil && *rel.CounterDistance != 0 {
+		parts = append(parts, "differs only in the counter")
+	}
+	if rel.BitMask != nil {
+		parts = append(parts, fmt.Sprintf("%d of %d bits differ", rel.DifferingBits, len(rel.BitMask)*8))
+	}
+	if len(parts) == 0 {
+		return "same format, no comparable components"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// deref returns the string a pointer refers to, or "" for nil
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
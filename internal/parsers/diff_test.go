@@ -0,0 +1,91 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// diffOf parses each input with the registry and diffs the results
+func diffOf(t *testing.T, inputs ...string) *types.IDDiff {
+	t.Helper()
+	registry := NewRegistry()
+	var infos []*types.IDInfo
+	for _, input := range inputs {
+		results := registry.ParseID(input, "")
+		if len(results) == 0 {
+			t.Fatalf("failed to parse %s", input)
+		}
+		infos = append(infos, results[0])
+	}
+	diff, err := DiffIDs(infos)
+	if err != nil {
+		t.Fatalf("DiffIDs failed: %v", err)
+	}
+	return diff
+}
+
+func TestDiffIDs_ObjectIDCounter(t *testing.T) {
+	diff := diffOf(t, "5f1d7f3e0000000000000001", "5f1d7f3e0000000000000002")
+
+	same := map[string]bool{}
+	for _, field := range diff.Fields {
+		same[field.Name] = field.Same
+	}
+	for name, expected := range map[string]bool{"id_type": true, "datetime": true, "node1": true, "node2": true, "sequence": false, "extra.counter_value": false} {
+		if got, ok := same[name]; !ok || got != expected {
+			t.Errorf("Field %s: expected same=%v, got %v (present %v)", name, expected, got, ok)
+		}
+	}
+
+	rel := diff.Relations[0]
+	if rel.TimeDeltaMs == nil || *rel.TimeDeltaMs != 0 {
+		t.Errorf("Expected zero time delta, got %v", rel.TimeDeltaMs)
+	}
+	if rel.SameNode1 == nil || !*rel.SameNode1 || rel.SameNode2 == nil || !*rel.SameNode2 {
+		t.Error("Expected same machine and process")
+	}
+	if rel.CounterDistance == nil || *rel.CounterDistance != 1 {
+		t.Errorf("Expected counter distance 1, got %v", rel.CounterDistance)
+	}
+	// ...01 vs ...02 differ in the two lowest bits
+	if rel.DifferingBits != 2 || rel.BitMask[11] != 0x03 {
+		t.Errorf("Expected 2 differing bits in the last byte, got %d (mask %x)", rel.DifferingBits, rel.BitMask)
+	}
+	if !strings.Contains(rel.Summary, "differs only in the counter") {
+		t.Errorf("Unexpected summary: %s", rel.Summary)
+	}
+}
+
+func TestDiffIDs_DifferentGenerators(t *testing.T) {
+	diff := diffOf(t, "5f1d7f3e0000000000000001", "507f1f77bcf86cd799439011", "01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if len(diff.Relations) != 2 {
+		t.Fatalf("Expected 2 relations, got %d", len(diff.Relations))
+	}
+
+	rel := diff.Relations[0]
+	if rel.TimeDeltaMs == nil || *rel.TimeDeltaMs >= 0 {
+		t.Errorf("Expected the second ObjectId to be older, got %v", rel.TimeDeltaMs)
+	}
+	if rel.SameNode1 == nil || *rel.SameNode1 {
+		t.Error("Expected different machines")
+	}
+	if !strings.Contains(rel.Summary, "different generator") || strings.Contains(rel.Summary, "counter") {
+		t.Errorf("Unexpected summary: %s", rel.Summary)
+	}
+
+	if rel := diff.Relations[1]; rel.BitMask != nil || !strings.HasPrefix(rel.Summary, "different formats") {
+		t.Errorf("Expected formats of different sizes to be compared field by field only, got %q", rel.Summary)
+	}
+	// The timestamps of different formats still give a time delta
+	if rel := diff.Relations[1]; rel.TimeDeltaMs == nil || !strings.Contains(rel.Summary, "created") || !strings.Contains(rel.Summary, "earlier") {
+		t.Errorf("Expected a time delta next to the format mismatch, got %q", rel.Summary)
+	}
+}
+
+func TestDiffIDs_NeedsTwo(t *testing.T) {
+	if _, err := DiffIDs(nil); err == nil {
+		t.Error("Expected an error for fewer than 2 IDs")
+	}
+}
@@ -0,0 +1,156 @@
+package parsers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// gs1Prefix maps a range of three-digit GS1 company prefixes to the issuing member organization
+type gs1Prefix struct {
+	from, to int
+	name     string
+}
+
+// Assigned GS1 prefixes; unassigned ranges (e.g. 140-199) are rejected so
+// millisecond timestamps with a matching check digit are not mistaken for EANs
+var gs1Prefixes = []gs1Prefix{
+	{0, 19, "United States and Canada"}, {20, 29, "Restricted distribution (in-store)"},
+	{30, 39, "United States (drugs)"}, {40, 49, "Restricted distribution (in-store)"},
+	{50, 59, "Coupons"}, {60, 139, "United States and Canada"},
+	{200, 299, "Restricted distribution (in-store)"}, {300, 379, "France and Monaco"},
+	{380, 380, "Bulgaria"}, {383, 383, "Slovenia"}, {385, 385, "Croatia"},
+	{387, 387, "Bosnia and Herzegovina"}, {389, 389, "Montenegro"}, {400, 440, "Germany"},
+	{450, 459, "Japan"}, {460, 469, "Russia"}, {470, 470, "Kyrgyzstan"}, {471, 471, "Taiwan"},
+	{474, 474, "Estonia"}, {475, 475, "Latvia"}, {476, 476, "Azerbaijan"}, {477, 477, "Lithuania"},
+	{478, 478, "Uzbekistan"}, {479, 479, "Sri Lanka"}, {480, 480, "Philippines"}, {481, 481, "Belarus"},
+	{482, 482, "Ukraine"}, {484, 484, "Moldova"}, {485, 485, "Armenia"}, {486, 486, "Georgia"},
+	{487, 487, "Kazakhstan"}, {489, 489, "Hong Kong"}, {490, 499, "Japan"},
+	{500, 509, "United Kingdom"}, {520, 521, "Greece"}, {528, 528, "Lebanon"}, {529, 529, "Cyprus"},
+	{530, 530, "Albania"}, {531, 531, "North Macedonia"}, {535, 535, "Malta"}, {539, 539, "Ireland"},
+	{540, 549, "Belgium and Luxembourg"}, {560, 560, "Portugal"}, {569, 569, "Iceland"},
+	{570, 579, "Denmark"}, {590, 590, "Poland"}, {594, 594, "Romania"}, {599, 599, "Hungary"},
+	{600, 601, "South Africa"}, {690, 699, "China"}, {700, 709, "Norway"}, {729, 729, "Israel"},
+	{730, 739, "Sweden"}, {750, 750, "Mexico"}, {754, 755, "Canada"}, {760, 769, "Switzerland"},
+	{770, 771, "Colombia"}, {779, 779, "Argentina"}, {780, 780, "Chile"}, {789, 790, "Brazil"},
+	{800, 839, "Italy"}, {840, 849, "Spain"}, {850, 850, "Cuba"}, {858, 858, "Slovakia"},
+	{859, 859, "Czech Republic"}, {860, 860, "Serbia"}, {869, 869, "Turkey"},
+	{870, 879, "Netherlands"}, {880, 880, "South Korea"}, {885, 885, "Thailand"},
+	{888, 888, "Singapore"}, {890, 890, "India"}, {893, 893, "Vietnam"}, {899, 899, "Indonesia"},
+	{900, 919, "Austria"}, {930, 939, "Australia"}, {940, 949, "New Zealand"}, {955, 955, "Malaysia"},
+	{958, 958, "Macau"}, {977, 977, "Serial publications (ISSN)"}, {978, 979, "Books (ISBN)"},
+	{980, 980, "Refund receipts"}, {981, 984, "Coupons"}, {990, 999, "Coupons"},
+}
+
+// EANParser handles GS1 article numbers: EAN-13, EAN-8, UPC-A and GTIN-14
+type EANParser struct{}
+
+func (p *EANParser) Name() string {
+	return "EAN"
+}
+
+func (p *EANParser) CanParse(input string) bool {
+	digits := stripSeparators(input)
+	if !isDigits(digits) || gs1CheckDigit(digits[:len(digits)-1]) != digits[len(digits)-1] {
+		return false
+	}
+	switch len(digits) {
+	case 8, 12, 14:
+		return true
+	case 13:
+		return gs1PrefixName(digits) != ""
+	}
+	return false
+}
+
+func (p *EANParser) Parse(input string) (*types.IDInfo, error) {
+	digits := stripSeparators(strings.TrimSpace(input))
+	if !isDigits(digits) {
+		return nil, fmt.Errorf("GS1 numbers contain only digits")
+	}
+
+	var version string
+	switch len(digits) {
+	case 8:
+		version = "EAN-8"
+	case 12:
+		version = "UPC-A"
+	case 13:
+		version = "EAN-13"
+	case 14:
+		version = "GTIN-14"
+	default:
+		return nil, fmt.Errorf("GS1 numbers have 8, 12, 13 or 14 digits, got %d", len(digits))
+	}
+	payload, check := digits[:len(digits)-1], digits[len(digits)-1]
+	if gs1CheckDigit(payload) != check {
+		return nil, fmt.Errorf("invalid GS1 check digit: expected %c", gs1CheckDigit(payload))
+	}
+
+	// All GS1 keys are right-aligned GTIN-14s; UPC-A is an EAN-13 with a leading zero
+	gtin := strings.Repeat("0", 14-len(digits)) + digits
+	extra := map[string]string{
+		"gtin14":      gtin,
+		"check_digit": string(check),
+		"checksum":    "valid (GS1 mod 10)",
+	}
+
+	// The company prefix is read from the EAN-13 form; EAN-8 has its own short prefix
+	prefix := gtin[1:4]
+	switch version {
+	case "EAN-8":
+		prefix = digits[:3]
+	case "GTIN-14":
+		extra["packaging_indicator"] = digits[:1]
+	case "UPC-A":
+		extra["number_system"] = digits[:1]
+		extra["ean13"] = "0" + digits
+	}
+	prefixName := gs1PrefixName(prefix)
+	if prefixName == "" {
+		prefixName = "unassigned"
+	}
+	extra["gs1_prefix"] = fmt.Sprintf("%s (%s)", prefix, prefixName)
+
+	hexStr, raw := digitsBinary(digits)
+	info := &types.IDInfo{
+		IDType:   "GS1 article number",
+		Version:  version,
+		Standard: digits,
+		Integer:  &digits,
+		Size:     len(digits) * 4, // BCD-sized
+		Hex:      hexStr,
+		Binary:   raw,
+		Extra:    extra,
+	}
+	info.Node1 = &prefix
+	info.Node2 = &prefixName
+
+	return info, nil
+}
+
+func (p *EANParser) Generate() (string, error) {
+	// Generate an EAN-13 under the German GS1 prefix range
+	body, err := randomDigits(9)
+	if err != nil {
+		return "", err
+	}
+	payload := "400" + body
+	return payload + string(gs1CheckDigit(payload)), nil
+}
+
+// gs1PrefixName returns the member organization for the first three digits of an EAN-13
+func gs1PrefixName(ean13 string) string {
+	prefix, err := strconv.Atoi(ean13[:3])
+	if err != nil {
+		return ""
+	}
+	for _, r := range gs1Prefixes {
+		if prefix >= r.from && prefix <= r.to {
+			return r.name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,60 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestEANParser(t *testing.T) {
+	parser := &EANParser{}
+
+	tests := []struct {
+		input   string
+		version string
+		prefix  string
+		country string
+	}{
+		{"4006381333931", "EAN-13", "400", "Germany"},
+		{"036000291452", "UPC-A", "003", "United States and Canada"},
+		{"96385074", "EAN-8", "963", "unassigned"},
+		{"73513537", "EAN-8", "735", "Sweden"},
+		{"10012345678902", "GTIN-14", "001", "United States and Canada"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Version != test.version {
+			t.Errorf("%s: expected %s, got %s", test.input, test.version, info.Version)
+		}
+		if *info.Node1 != test.prefix || *info.Node2 != test.country {
+			t.Errorf("%s: expected prefix %s (%s), got %s (%s)", test.input, test.prefix, test.country, *info.Node1, *info.Node2)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"4006381333932", // Wrong check digit
+		"1792327516125", // Millisecond timestamp under the unassigned 179 prefix
+		"12345",
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated EAN is not valid: %s", generated)
+	}
+}
@@ -0,0 +1,108 @@
+package parsers
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+)
+
+func TestElasticsearchParser_Name(t *testing.T) {
+	parser := &ElasticsearchParser{}
+	if parser.Name() != "Elasticsearch" {
+		t.Errorf("Expected name 'Elasticsearch', got '%s'", parser.Name())
+	}
+}
+
+func TestElasticsearchParser_Parse(t *testing.T) {
+	parser := &ElasticsearchParser{}
+
+	// Timestamp 0x018bd02f2e9b ms (November 2023), sequence 0x0a0b0c
+	raw := []byte{
+		0x0c, 0x0a, // sequence bytes 0 and 2
+		0x2f, 0xd0, 0x8b, 0x01, // timestamp bytes 2-5
+		0x02, 0x42, 0xac, 0x11, 0x00, 0x02, // MAC
+		0x2e, 0x0b, 0x9b, // timestamp byte 1, sequence byte 1, timestamp byte 0
+	}
+	input := base64.RawURLEncoding.EncodeToString(raw)
+
+	if !parser.CanParse(input) {
+		t.Fatalf("Expected to parse Elasticsearch ID %s", input)
+	}
+
+	info, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	expected := time.UnixMilli(0x018bd02f2e9b)
+	if info.DateTime == nil || !info.DateTime.Equal(expected) {
+		t.Errorf("Expected DateTime %v, got %v", expected, info.DateTime)
+	}
+	if info.Sequence == nil || *info.Sequence != 0x0a0b0c {
+		t.Errorf("Expected sequence %d, got %v", 0x0a0b0c, info.Sequence)
+	}
+	if info.Extra["mac_address"] != "02:42:ac:11:00:02" {
+		t.Errorf("Expected MAC 02:42:ac:11:00:02, got %q", info.Extra["mac_address"])
+	}
+	if info.Size != 120 {
+		t.Errorf("Expected size 120, got %d", info.Size)
+	}
+}
+
+func TestElasticsearchParser_CanParse(t *testing.T) {
+	parser := &ElasticsearchParser{}
+
+	invalid := []string{
+		"",
+		"short",
+		"AAAAAAAAAAAAAAAAAAAA",  // timestamp 0
+		"____________________",  // timestamp far in the future
+		"9m4e2mr0ui3e8a215n4g",  // Xid
+		"AAAAAAAAAAAAAAAAAAA!",  // invalid character
+		"AAAAAAAAAAAAAAAAAAAAA", // 21 characters
+	}
+
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+}
+
+func TestElasticsearchParser_Generate(t *testing.T) {
+	parser := &ElasticsearchParser{}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		id, err := parser.Generate()
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if len(id) != 20 {
+			t.Fatalf("Expected 20 characters, got %d: %s", len(id), id)
+		}
+		if seen[id] {
+			t.Fatalf("Generated duplicate ID: %s", id)
+		}
+		seen[id] = true
+	}
+
+	id, _ := parser.Generate()
+	info, err := parser.Parse(id)
+	if err != nil {
+		t.Fatalf("Failed to parse generated ID: %v", err)
+	}
+	if diff := time.Since(*info.DateTime); diff < -time.Second || diff > time.Minute {
+		t.Errorf("Generated ID timestamp should be recent, got %v", info.DateTime)
+	}
+
+	first, _ := parser.Parse(id)
+	next, _ := parser.Generate()
+	second, _ := parser.Parse(next)
+	if *second.Sequence != (*first.Sequence+1)&0xffffff {
+		t.Errorf("Expected consecutive sequence numbers, got %d then %d", *first.Sequence, *second.Sequence)
+	}
+	if first.Extra["mac_address"] != second.Extra["mac_address"] {
+		t.Error("Expected the MAC component to be stable across generations")
+	}
+}
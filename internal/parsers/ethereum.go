@@ -0,0 +1,99 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+	"golang.org/x/crypto/sha3"
+)
+
+var ethereumAddressRegex = regexp.MustCompile(`^0[xX][0-9a-fA-F]{40}$`)
+
+// EthereumParser handles Ethereum addresses with EIP-55 mixed-case checksums
+type EthereumParser struct{}
+
+func (p *EthereumParser) Name() string {
+	return "Ethereum"
+}
+
+func (p *EthereumParser) CanParse(input string) bool {
+	return ethereumAddressRegex.MatchString(input)
+}
+
+func (p *EthereumParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid Ethereum address format")
+	}
+
+	body := input[2:]
+	raw, err := hex.DecodeString(body)
+	if err != nil {
+		return nil, err
+	}
+
+	checksummed := eip55Checksum(body)
+	extra := map[string]string{
+		"network":      "Ethereum (and EVM-compatible chains)",
+		"payload_hash": strings.ToLower(body),
+		"checksummed":  checksummed,
+		"checksum_alg": "EIP-55 (Keccak-256 mixed case)",
+	}
+
+	// All-lowercase or all-uppercase addresses carry no checksum at all
+	switch {
+	case body == strings.ToLower(body) || body == strings.ToUpper(body):
+		extra["checksum_valid"] = "n/a (no mixed-case checksum)"
+	case "0x"+body == checksummed:
+		extra["checksum_valid"] = "true"
+	default:
+		extra["checksum_valid"] = "false"
+	}
+
+	entropy := 160
+
+	return &types.IDInfo{
+		IDType:   "Ethereum Address",
+		Standard: checksummed,
+		Size:     160,
+		Entropy:  &entropy,
+		Hex:      strings.ToLower(body),
+		Binary:   raw,
+		Extra:    extra,
+	}, nil
+}
+
+func (p *EthereumParser) Generate() (string, error) {
+	raw := make([]byte, 20)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	return eip55Checksum(hex.EncodeToString(raw)), nil
+}
+
+// eip55Checksum returns the 0x-prefixed EIP-55 mixed-case form of a 40-char hex address
+func eip55Checksum(address string) string {
+	lower := strings.ToLower(address)
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(lower))
+	hash := hasher.Sum(nil)
+
+	result := []byte(lower)
+	for i, c := range result {
+		if c < 'a' {
+			continue
+		}
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			result[i] = c - 32
+		}
+	}
+	return "0x" + string(result)
+}
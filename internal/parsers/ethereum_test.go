@@ -0,0 +1,53 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestEthereumParser(t *testing.T) {
+	parser := &EthereumParser{}
+
+	tests := []struct {
+		input         string
+		checksumValid string
+	}{
+		{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "true"},
+		{"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", "true"},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", "n/a (no mixed-case checksum)"},
+		{"0x5AAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "false"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Extra["checksum_valid"] != test.checksumValid {
+			t.Errorf("%s: expected checksum_valid %q, got %q", test.input, test.checksumValid, info.Extra["checksum_valid"])
+		}
+		if info.Extra["checksummed"] != "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed" && info.Extra["checksummed"] != "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359" {
+			t.Errorf("%s: unexpected checksummed form %s", test.input, info.Extra["checksummed"])
+		}
+	}
+
+	invalid := []string{"", "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAe", "0xZZAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	info, err := parser.Parse(generated)
+	if err != nil || info.Extra["checksum_valid"] == "false" {
+		t.Errorf("Generated address is not valid: %s", generated)
+	}
+}
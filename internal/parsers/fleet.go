@@ -0,0 +1,227 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// fleetSkewWindow is how many IDs on each side of a sample make up its reference time
+const fleetSkewWindow = 50
+
+// InferFleet groups IDs by the generator components they embed (machine, process or node in
+// Node1/Node2) and reports each generator's volume, time range, rate and counter behaviour.
+// When the IDs are in arrival order, such as rows sorted by insertion time, each generator's
+// clock is compared with the IDs that arrived around the same time from the other generators;
+// generators whose median offset exceeds skewThreshold are reported as drifting.
+func (r *Registry) InferFleet(inputs []string, forceFormat string, skewThreshold time.Duration) (*types.FleetReport, error) {
+	_, infos, _, skipped := r.parseCorpus(inputs, forceFormat)
+	if len(infos) < 2 {
+		return nil, fmt.Errorf("need at least 2 parseable IDs of one format, got %d", len(infos))
+	}
+
+	report := &types.FleetReport{Format: infos[0].IDType, Count: len(infos), Skipped: len(skipped)}
+	byKey := map[string]*types.GeneratorStat{}
+	members := map[string][]int{}
+	keys := make([]string, len(infos))
+	for i, info := range infos {
+		if info.Node1 == nil && info.Node2 == nil {
+			return nil, fmt.Errorf("%s does not embed a machine, process or node component", info.IDType)
+		}
+		gen := &types.GeneratorStat{}
+		if info.Node1 != nil {
+			gen.Node1 = *info.Node1
+		}
+		if info.Node2 != nil {
+			gen.Node2 = *info.Node2
+		}
+		key := gen.Node1 + "\x00" + gen.Node2
+		if existing, ok := byKey[key]; ok {
+			gen = existing
+		} else {
+			byKey[key] = gen
+			report.Generators = append(report.Generators, gen)
+		}
+		keys[i] = key
+		members[key] = append(members[key], i)
+		gen.Count++
+		if info.DateTime != nil {
+			t := *info.DateTime
+			if gen.FirstSeen == nil || t.Before(*gen.FirstSeen) {
+				gen.FirstSeen = &t
+			}
+			if gen.LastSeen == nil || t.After(*gen.LastSeen) {
+				gen.LastSeen = &t
+			}
+		}
+	}
+
+	for key, gen := range byKey {
+		if gen.FirstSeen != nil && gen.Count > 1 {
+			if span := gen.LastSeen.Sub(*gen.FirstSeen).Seconds(); span > 0 {
+				gen.RatePerSecond = float64(gen.Count-1) / span
+			}
+		}
+		counterStats(gen, infos, members[key])
+	}
+	clockSkew(byKey, infos, keys)
+
+	sort.SliceStable(report.Generators, func(i, j int) bool {
+		return report.Generators[i].Count > report.Generators[j].Count
+	})
+	report.Findings = fleetFindings(report, skewThreshold)
+	return report, nil
+}
+
+// counterStats orders one generator's IDs by time and counter and counts the counter's gaps,
+// resets and repeats. Gaps are IDs from the generator that are missing from the sample; they are
+// only counted for counters that step by 1, not for those with a counter_step detail (NUID).
+func counterStats(gen *types.GeneratorStat, infos []*types.IDInfo, members []int) {
+	var ordered []*types.IDInfo
+	distinct := map[int64]bool{}
+	for _, i := range members {
+		if infos[i].Sequence != nil {
+			ordered = append(ordered, infos[i])
+			distinct[*infos[i].Sequence] = true
+		}
+	}
+	if len(ordered) < 2 {
+		return
+	}
+	if len(distinct) == 1 {
+		// A UUIDv1 clock sequence only changes when the generator restarts
+		gen.CounterConstant = true
+		return
+	}
+	sort.SliceStable(ordered, func(a, b int) bool {
+		ta, tb := ordered[a].DateTime, ordered[b].DateTime
+		if ta != nil && tb != nil && !ta.Equal(*tb) {
+			return ta.Before(*tb)
+		}
+		return *ordered[a].Sequence < *ordered[b].Sequence
+	})
+	gen.CounterStep = ordered[0].Extra["counter_step"]
+	for i := 1; i < len(ordered); i++ {
+		prev, cur := ordered[i-1], ordered[i]
+		sameTime := prev.DateTime == nil || cur.DateTime == nil || prev.DateTime.Equal(*cur.DateTime)
+		switch d := *cur.Sequence - *prev.Sequence; {
+		case d < 0:
+			gen.CounterResets++
+		case d == 0 && sameTime:
+			gen.CounterRepeats++
+		case d > 1 && gen.CounterStep == "":
+			gen.CounterGaps++
+			gen.MissingValues += d - 1
+		}
+	}
+}
+
+// clockSkew estimates each generator's clock offset. For every pair of generators, each ID is
+// compared with the median time of the other generator's IDs within fleetSkewWindow positions of
+// it in the input, and the pair's offset is the median of those differences. Offsets are then
+// given relative to the generator that agrees best with the rest, so one drifting replica does
+// not drag the reference with it.
+func clockSkew(byKey map[string]*types.GeneratorStat, infos []*types.IDInfo, keys []string) {
+	if len(byKey) < 2 {
+		return
+	}
+	diffs := map[[2]string][]float64{}
+	for i, info := range infos {
+		if info.DateTime == nil {
+			continue
+		}
+		nearby := map[string][]float64{}
+		for j := max(0, i-fleetSkewWindow); j < min(len(infos), i+fleetSkewWindow+1); j++ {
+			if keys[j] != keys[i] && infos[j].DateTime != nil {
+				nearby[keys[j]] = append(nearby[keys[j]], float64(infos[j].DateTime.UnixNano()))
+			}
+		}
+		for other, times := range nearby {
+			pair := [2]string{keys[i], other}
+			diffs[pair] = append(diffs[pair], (float64(info.DateTime.UnixNano())-median(times))/1e6)
+		}
+	}
+
+	offsets := map[[2]string]float64{}
+	for pair, list := range diffs {
+		offsets[pair] = median(list)
+	}
+	reference, best := "", 0.0
+	for key := range byKey {
+		var spread []float64
+		for pair, offset := range offsets {
+			if pair[0] == key {
+				spread = append(spread, math.Abs(offset))
+			}
+		}
+		if len(spread) == 0 {
+			continue
+		}
+		m := median(spread)
+		if reference == "" || m < best || m == best && byKey[key].Count > byKey[reference].Count {
+			reference, best = key, m
+		}
+	}
+	if reference == "" {
+		return
+	}
+
+	zero := 0.0
+	byKey[reference].ClockSkewMs = &zero
+	for key, gen := range byKey {
+		if offset, ok := offsets[[2]string{key, reference}]; ok && key != reference {
+			ms := offset
+			gen.ClockSkewMs = &ms
+		}
+	}
+}
+
+// fleetFindings summarizes the generators and flags counters and clocks that look wrong
+func fleetFindings(report *types.FleetReport, skewThreshold time.Duration) []string {
+	generators := "generators"
+	if len(report.Generators) == 1 {
+		generators = "generator"
+	}
+	findings := []string{fmt.Sprintf("%d distinct %s in %d IDs", len(report.Generators), generators, report.Count)}
+	for _, gen := range report.Generators {
+		name := generatorName(gen)
+		if gen.ClockSkewMs != nil && skewThreshold > 0 {
+			skew := time.Duration(*gen.ClockSkewMs * float64(time.Millisecond))
+			if skew > skewThreshold || -skew > skewThreshold {
+				direction := "ahead of"
+				if skew < 0 {
+					direction, skew = "behind", -skew
+				}
+				findings = append(findings, fmt.Sprintf("%s runs %s %s the rest of the fleet", name, skew.Round(time.Millisecond), direction))
+			}
+		}
+		if gen.CounterRepeats > 0 {
+			findings = append(findings, fmt.Sprintf("%s repeats counter values %d times at the same time: two processes share this node ID", name, gen.CounterRepeats))
+		}
+	}
+	return findings
+}
+
+// generatorName labels a generator by its node components
+func generatorName(gen *types.GeneratorStat) string {
+	if gen.Node2 == "" {
+		return gen.Node1
+	}
+	if gen.Node1 == "" {
+		return gen.Node2
+	}
+	return gen.Node1 + "/" + gen.Node2
+}
+
+// median returns the middle value of a list, sorting it in place
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
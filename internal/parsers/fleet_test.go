@@ -0,0 +1,136 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/snowflake"
+	"github.com/google/uuid"
+	"github.com/nats-io/nuid"
+)
+
+// fleetObjectIDs builds ObjectIds from three processes in arrival order; the third process's
+// clock runs ten seconds fast and its counter skips every fifth value
+func fleetObjectIDs() []string {
+	machines := []string{"aabbccdd01", "aabbccdd02", "1122334455"}
+	counters := []int{100, 5000, 90000}
+	var ids []string
+	for i := 0; i < 300; i++ {
+		gen := i % 3
+		seconds := 1700000000 + i/3
+		if gen == 2 {
+			seconds += 10
+			if i%5 == 0 {
+				counters[gen]++
+			}
+		}
+		counters[gen]++
+		ids = append(ids, fmt.Sprintf("%08x%s%06x", seconds, machines[gen], counters[gen]))
+	}
+	return ids
+}
+
+func TestInferFleet_ObjectID(t *testing.T) {
+	report, err := NewRegistry().InferFleet(fleetObjectIDs(), "", time.Second)
+	if err != nil {
+		t.Fatalf("InferFleet failed: %v", err)
+	}
+	if report.Count != 300 || len(report.Generators) != 3 {
+		t.Fatalf("Expected 3 generators in 300 IDs, got %d in %d", len(report.Generators), report.Count)
+	}
+
+	for _, gen := range report.Generators {
+		if gen.Count != 100 {
+			t.Errorf("Expected 100 IDs from %s/%s, got %d", gen.Node1, gen.Node2, gen.Count)
+		}
+		if gen.FirstSeen == nil || gen.LastSeen.Sub(*gen.FirstSeen) != 99*time.Second {
+			t.Errorf("Expected a 99s span for %s/%s, got %v - %v", gen.Node1, gen.Node2, gen.FirstSeen, gen.LastSeen)
+		}
+		if gen.ClockSkewMs == nil {
+			t.Fatalf("Expected a clock skew for %s/%s", gen.Node1, gen.Node2)
+		}
+		drifting := gen.Node1 == "112233"
+		if drifting && (math.Abs(*gen.ClockSkewMs-10000) > 1000 || gen.CounterGaps == 0) {
+			t.Errorf("Expected ~10s skew and counter gaps for the drifting process, got %v ms and %d gaps", *gen.ClockSkewMs, gen.CounterGaps)
+		}
+		if !drifting && (math.Abs(*gen.ClockSkewMs) > 1000 || gen.CounterGaps != 0) {
+			t.Errorf("Expected no skew or gaps for %s/%s, got %v ms and %d gaps", gen.Node1, gen.Node2, *gen.ClockSkewMs, gen.CounterGaps)
+		}
+	}
+
+	var found bool
+	for _, finding := range report.Findings {
+		found = found || strings.HasPrefix(finding, "112233/4455 runs") && strings.Contains(finding, "ahead of")
+	}
+	if !found {
+		t.Errorf("Expected a clock drift finding, got %v", report.Findings)
+	}
+}
+
+func TestInferFleet_SharedNode(t *testing.T) {
+	// Two processes configured with the same Snowflake node ID produce the same sequence in one millisecond
+	ids := []string{"1777150623882019211", "1777150623882019211", "1777150623882019212"}
+	report, err := NewRegistry().InferFleet(ids, "snowflake", time.Second)
+	if err != nil {
+		t.Fatalf("InferFleet failed: %v", err)
+	}
+	if len(report.Generators) != 1 || report.Generators[0].CounterRepeats != 1 {
+		t.Fatalf("Expected one generator with a repeated counter, got %+v", report.Generators)
+	}
+}
+
+func TestInferFleet_SnowflakeAutoDetect(t *testing.T) {
+	// Snowflakes from two nodes, without -f: Sqids also accepts the numbers but decodes no nodes
+	var nodes []*snowflake.Node
+	for _, id := range []int64{7, 300} {
+		node, err := snowflake.NewNode(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes = append(nodes, node)
+	}
+	var ids []string
+	for i := 0; i < 40; i++ {
+		ids = append(ids, nodes[i%2].Generate().String())
+	}
+	report, err := NewRegistry().InferFleet(ids, "", time.Second)
+	if err != nil {
+		t.Fatalf("InferFleet failed: %v", err)
+	}
+	if report.Format != "Snowflake" || len(report.Generators) != 2 {
+		t.Fatalf("Expected 2 Snowflake generators, got %s with %+v", report.Format, report.Generators)
+	}
+	for _, gen := range report.Generators {
+		if gen.Count != 20 || gen.CounterResets != 0 {
+			t.Errorf("Expected 20 IDs in counter order from %s, got %+v", generatorName(gen), gen)
+		}
+	}
+}
+
+func TestInferFleet_NUID(t *testing.T) {
+	// A NUID counter grows by a random 33-333, so consecutive IDs are not gaps
+	gen := nuid.New()
+	var ids []string
+	for i := 0; i < 20; i++ {
+		ids = append(ids, gen.Next())
+	}
+	report, err := NewRegistry().InferFleet(ids, "nuid", time.Second)
+	if err != nil {
+		t.Fatalf("InferFleet failed: %v", err)
+	}
+	if len(report.Generators) != 1 || report.Generators[0].CounterGaps != 0 || report.Generators[0].CounterStep == "" {
+		t.Fatalf("Expected one generator with a random step and no gaps, got %+v", report.Generators)
+	}
+	if report.Findings[0] != "1 distinct generator in 20 IDs" {
+		t.Errorf("Unexpected summary %q", report.Findings[0])
+	}
+}
+
+func TestInferFleet_NoNode(t *testing.T) {
+	if _, err := NewRegistry().InferFleet([]string{uuid.New().String(), uuid.New().String()}, "", time.Second); err == nil {
+		t.Error("Expected an error for IDs without generator components")
+	}
+}
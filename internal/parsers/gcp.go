@@ -0,0 +1,140 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+var (
+	gcpNumericIDRegex    = regexp.MustCompile(`^(\d{12}|\d{19})$`)
+	gcpProjectIDRegex    = regexp.MustCompile(`^[a-z][a-z0-9-]{4,28}[a-z0-9]$`)
+	gcpResourcePathRegex = regexp.MustCompile(`^(?://([a-z0-9.-]+\.googleapis\.com)/)?projects/([^/]+)((?:/[^/]+/[^/]+)*)$`)
+)
+
+// GCPParser handles Google Cloud project numbers, numeric resource IDs and resource paths
+type GCPParser struct{}
+
+func (p *GCPParser) Name() string {
+	return "GCP"
+}
+
+func (p *GCPParser) CanParse(input string) bool {
+	if gcpNumericIDRegex.MatchString(input) {
+		_, err := strconv.ParseUint(input, 10, 64)
+		return err == nil
+	}
+
+	m := gcpResourcePathRegex.FindStringSubmatch(input)
+	if m == nil {
+		return false
+	}
+	return gcpProjectIDRegex.MatchString(m[2]) || gcpNumericIDRegex.MatchString(m[2])
+}
+
+func (p *GCPParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid GCP identifier format")
+	}
+
+	if gcpNumericIDRegex.MatchString(input) {
+		return p.parseNumeric(input)
+	}
+	return p.parsePath(input)
+}
+
+func (p *GCPParser) parseNumeric(input string) (*types.IDInfo, error) {
+	value, err := strconv.ParseUint(input, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	// Project numbers are 12 digits; Compute Engine instance and disk IDs are 19
+	resourceType := "project number"
+	if len(input) == 19 {
+		resourceType = "resource ID (e.g. Compute Engine instance)"
+	}
+
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, value)
+
+	return &types.IDInfo{
+		IDType:   fmt.Sprintf("GCP %s", resourceType),
+		Standard: input,
+		Integer:  &input,
+		Size:     64,
+		Hex:      fmt.Sprintf("%016x", value),
+		Binary:   raw,
+		Extra: map[string]string{
+			"provider":      "GCP",
+			"resource_type": resourceType,
+			"digits":        fmt.Sprintf("%d", len(input)),
+			"note":          "numeric GCP IDs carry no embedded structure",
+		},
+	}, nil
+}
+
+func (p *GCPParser) parsePath(input string) (*types.IDInfo, error) {
+	m := gcpResourcePathRegex.FindStringSubmatch(input)
+	host, project, rest := m[1], m[2], m[3]
+
+	extra := map[string]string{
+		"provider": "GCP",
+		"project":  project,
+	}
+	if host != "" {
+		extra["service"] = strings.TrimSuffix(host, ".googleapis.com")
+	}
+
+	// Walk the collection/name pairs, e.g. /zones/us-central1-a/instances/vm-1
+	segments := strings.Split(strings.TrimPrefix(rest, "/"), "/")
+	resourceType := "project"
+	resourceName := project
+	var location string
+	for i := 0; i+1 < len(segments); i += 2 {
+		collection, name := segments[i], segments[i+1]
+		switch collection {
+		case "zones", "regions", "locations":
+			location = name
+			extra[strings.TrimSuffix(collection, "s")] = name
+		default:
+			resourceType = strings.TrimSuffix(collection, "s")
+			resourceName = name
+		}
+	}
+	extra["resource_type"] = resourceType
+	extra["resource_name"] = resourceName
+
+	info := &types.IDInfo{
+		IDType:   fmt.Sprintf("GCP resource path (%s)", resourceType),
+		Standard: input,
+		Size:     len(input) * 8,
+		Hex:      hex.EncodeToString([]byte(input)),
+		Binary:   []byte(input),
+		Node1:    &project,
+		Extra:    extra,
+	}
+	if location != "" {
+		info.Node2 = &location
+	}
+	return info, nil
+}
+
+func (p *GCPParser) Generate() (string, error) {
+	// Generate a 19-digit numeric resource ID like Compute Engine instance IDs
+	min := new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+	span := new(big.Int).Sub(new(big.Int).SetUint64(1<<63), min)
+	n, err := rand.Int(rand.Reader, span)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random number: %v", err)
+	}
+	return n.Add(n, min).String(), nil
+}
@@ -0,0 +1,49 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestGCPParser(t *testing.T) {
+	parser := &GCPParser{}
+
+	info, err := parser.Parse("//compute.googleapis.com/projects/my-project-123/zones/us-central1-a/instances/vm-1")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	expected := map[string]string{
+		"service":       "compute",
+		"project":       "my-project-123",
+		"zone":          "us-central1-a",
+		"resource_type": "instance",
+		"resource_name": "vm-1",
+	}
+	for key, value := range expected {
+		if info.Extra[key] != value {
+			t.Errorf("Expected %s=%s, got %s", key, value, info.Extra[key])
+		}
+	}
+
+	info, err = parser.Parse("123456789012")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Extra["resource_type"] != "project number" {
+		t.Errorf("Expected project number, got %s", info.Extra["resource_type"])
+	}
+
+	invalid := []string{"", "12345", "projects/A/zones/x", "projects/my-project/zones", "1234567890123"}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if len(generated) != 19 || !parser.CanParse(generated) {
+		t.Errorf("Generated ID is not valid: %s", generated)
+	}
+}
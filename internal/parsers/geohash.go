@@ -0,0 +1,129 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// canParseGeohash only claims lowercase 5-12 character strings that mix digits and letters, with
+// at least one letter beyond f; shorter, all-letter or hex-only strings are too likely to be words,
+// hash fragments or other IDs
+func canParseGeohash(input string) bool {
+	if len(input) < 5 || len(input) > 12 {
+		return false
+	}
+	hasDigit, hasLetter := false, false
+	for i := 0; i < len(input); i++ {
+		c := input[i]
+		if !strings.ContainsRune(geohashAlphabet, rune(c)) {
+			return false
+		}
+		if c >= '0' && c <= '9' {
+			hasDigit = true
+		} else {
+			hasLetter = true
+		}
+	}
+	return hasDigit && hasLetter && strings.ContainsAny(input, "ghjkmnpqrstuvwxyz")
+}
+
+// geohashBounds decodes a geohash into its latitude and longitude bounds.
+// Bits alternate between longitude and latitude, starting with longitude.
+func geohashBounds(hash string) (latMin, latMax, lonMin, lonMax float64, err error) {
+	latMin, latMax, lonMin, lonMax = -90, 90, -180, 180
+	even := true
+	for i := 0; i < len(hash); i++ {
+		idx := strings.IndexByte(geohashAlphabet, hash[i])
+		if idx < 0 {
+			return 0, 0, 0, 0, fmt.Errorf("invalid Geohash character %q", hash[i])
+		}
+		for bit := 4; bit >= 0; bit-- {
+			set := idx>>bit&1 == 1
+			if even {
+				mid := (lonMin + lonMax) / 2
+				if set {
+					lonMin = mid
+				} else {
+					lonMax = mid
+				}
+			} else {
+				mid := (latMin + latMax) / 2
+				if set {
+					latMin = mid
+				} else {
+					latMax = mid
+				}
+			}
+			even = !even
+		}
+	}
+	return latMin, latMax, lonMin, lonMax, nil
+}
+
+// parseGeohash decodes a geohash to the center and bounding box of its cell
+func parseGeohash(input string) (*types.IDInfo, error) {
+	hash := strings.ToLower(strings.TrimSpace(input))
+	if hash == "" || len(hash) > 22 {
+		return nil, fmt.Errorf("Geohashes have 1 to 22 characters")
+	}
+	latMin, latMax, lonMin, lonMax, err := geohashBounds(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	lat, lon := (latMin+latMax)/2, (lonMin+lonMax)/2
+	// Cell size in metres, measured at the cell's latitude
+	const metresPerDegree = 111320.0
+	height := (latMax - latMin) * metresPerDegree
+	width := (lonMax - lonMin) * metresPerDegree * math.Cos(lat*math.Pi/180)
+
+	// Pack the interleaved bits so Hex and Binary show the raw value
+	value := new(big.Int)
+	for i := 0; i < len(hash); i++ {
+		value.Lsh(value, 5)
+		value.Or(value, big.NewInt(int64(strings.IndexByte(geohashAlphabet, hash[i]))))
+	}
+	raw := value.FillBytes(make([]byte, (len(hash)*5+7)/8))
+
+	center := fmt.Sprintf("%.6f, %.6f", lat, lon)
+	entropy := len(hash) * 5
+	info := &types.IDInfo{
+		IDType:   "Geohash",
+		Version:  fmt.Sprintf("precision %d", len(hash)),
+		Standard: hash,
+		Size:     len(hash) * 5,
+		Entropy:  &entropy,
+		Node1:    &center,
+		Hex:      fmt.Sprintf("%x", raw),
+		Binary:   raw,
+		Extra: map[string]string{
+			"alphabet":     fmt.Sprintf("Geohash (%s)", geohashAlphabet),
+			"latitude":     fmt.Sprintf("%.6f", lat),
+			"longitude":    fmt.Sprintf("%.6f", lon),
+			"bounding_box": fmt.Sprintf("lat %.6f to %.6f, lon %.6f to %.6f", latMin, latMax, lonMin, lonMax),
+			"cell_size":    formatGeohashCell(width, height),
+			"bits":         fmt.Sprintf("%d (%d longitude, %d latitude)", len(hash)*5, (len(hash)*5+1)/2, len(hash)*5/2),
+		},
+	}
+	return info, nil
+}
+
+// formatGeohashCell renders a cell's width and height in metres or kilometres
+func formatGeohashCell(width, height float64) string {
+	format := func(m float64) string {
+		if m >= 1000 {
+			return fmt.Sprintf("%.1f km", m/1000)
+		}
+		if m >= 1 {
+			return fmt.Sprintf("%.1f m", m)
+		}
+		return fmt.Sprintf("%.1f cm", m*100)
+	}
+	return fmt.Sprintf("%s x %s", format(width), format(height))
+}
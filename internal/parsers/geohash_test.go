@@ -0,0 +1,68 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGeohashParser(t *testing.T) {
+	parser := &Base32Parser{variant: "geohash"}
+
+	if parser.Name() != "Geohash" {
+		t.Errorf("Expected name Geohash, got %s", parser.Name())
+	}
+
+	info, err := parser.Parse("u4pruydqqvj")
+	if err != nil {
+		t.Fatalf("Failed to parse Geohash: %v", err)
+	}
+	if info.Extra["latitude"] != "57.649111" || info.Extra["longitude"] != "10.407440" {
+		t.Errorf("Unexpected center %s, %s", info.Extra["latitude"], info.Extra["longitude"])
+	}
+	if info.Version != "precision 11" {
+		t.Errorf("Expected precision 11, got %s", info.Version)
+	}
+
+	info, err = parser.Parse("ezs42")
+	if err != nil {
+		t.Fatalf("Failed to parse Geohash: %v", err)
+	}
+	if info.Extra["bounding_box"] != "lat 42.583008 to 42.626953, lon -5.625000 to -5.581055" {
+		t.Errorf("Unexpected bounding box %s", info.Extra["bounding_box"])
+	}
+	if !strings.HasSuffix(info.Extra["cell_size"], "km") {
+		t.Errorf("Expected a cell size in km, got %s", info.Extra["cell_size"])
+	}
+
+	for _, input := range []string{"u4pruydqqvj", "ezs42", "9q8yyk8yt"} {
+		if !parser.CanParse(input) {
+			t.Errorf("Expected to detect Geohash %s", input)
+		}
+	}
+	// Geohash excludes a, i, l and o, and plain words or numbers are not claimed
+	for _, input := range []string{"hello", "abcdefgh", "12345678", "U4PRUYDQQVJ", "u4pr"} {
+		if parser.CanParse(input) {
+			t.Errorf("Expected to reject %s", input)
+		}
+	}
+	// Hex-only strings are more likely hash fragments
+	if parser.CanParse("d2c0f") {
+		t.Error("Expected to reject a hex-only string")
+	}
+	if _, err := parser.Parse("u4pa"); err == nil {
+		t.Error("Expected error for a character outside the Geohash alphabet")
+	}
+}
+
+func TestGeohashParser_AutoDetect(t *testing.T) {
+	// Geohash is tried ahead of CUID2, which would otherwise claim any short lowercase string
+	for _, input := range []string{"u4pruydqqvj", "9q8yyk8yt", "gcpvj0"} {
+		results := ParseID(input, "")
+		if len(results) == 0 || results[0].IDType != "Geohash" {
+			t.Errorf("Expected %s to be detected as a Geohash first", input)
+		}
+	}
+	if results := ParseID("ezs42", "gh"); len(results) != 1 || results[0].IDType != "Geohash" {
+		t.Error("Expected -f gh to parse a Geohash")
+	}
+}
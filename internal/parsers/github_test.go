@@ -0,0 +1,93 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestGitHubNodeIDParser(t *testing.T) {
+	parser := &GitHubNodeIDParser{}
+
+	tests := []struct {
+		input      string
+		objectType string
+		databaseID string
+		scopeID    string
+	}{
+		{"MDQ6VXNlcjE=", "User", "1", ""},
+		{"MDEwOlJlcG9zaXRvcnkxMjk2MjY5", "Repository", "1296269", ""},
+		{"U_kgDOAAECAw", "User", "66051", ""},
+		{"I_kwDOAAECA84ABAUG", "Issue", "263430", "66051"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Extra["object_type"] != test.objectType {
+			t.Errorf("%s: expected type %s, got %s", test.input, test.objectType, info.Extra["object_type"])
+		}
+		if info.Extra["database_id"] != test.databaseID {
+			t.Errorf("%s: expected database ID %s, got %s", test.input, test.databaseID, info.Extra["database_id"])
+		}
+		if info.Extra["scope_id"] != test.scopeID {
+			t.Errorf("%s: expected scope ID %q, got %q", test.input, test.scopeID, info.Extra["scope_id"])
+		}
+	}
+
+	// Hex and size describe the decoded payload, not the base64 text
+	for input, want := range map[string]string{"U_kgDOAAECAw": "9200ce00010203", "MDQ6VXNlcjE=": "30343a5573657231"} {
+		info, err := parser.Parse(input)
+		if err != nil || info.Hex != want || info.Size != len(want)*4 {
+			t.Errorf("%s: expected payload %s, got %+v (%v)", input, want, info, err)
+		}
+	}
+
+	invalid := []string{"", "MDQ6VXNlcjE", "aGVsbG8gd29ybGQ=", "ZZ_kgDOAAECAw", "U_notmsgpack!"}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated node ID is not valid: %s", generated)
+	}
+}
+
+func TestDecodeMsgpack(t *testing.T) {
+	// {"a": [1, -1, "x"], "b": true}
+	data := []byte{0x82, 0xa1, 'a', 0x93, 0x01, 0xff, 0xa1, 'x', 0xa1, 'b', 0xc3}
+	value, used, err := decodeMsgpack(data)
+	if err != nil {
+		t.Fatalf("decodeMsgpack failed: %v", err)
+	}
+	if used != len(data) {
+		t.Errorf("Expected to consume %d bytes, consumed %d", len(data), used)
+	}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		t.Fatalf("Expected map, got %T", value)
+	}
+	array, ok := m["a"].([]interface{})
+	if !ok || len(array) != 3 || array[0] != int64(1) || array[1] != int64(-1) || array[2] != "x" {
+		t.Errorf("Unexpected array value: %#v", m["a"])
+	}
+	if m["b"] != true {
+		t.Errorf("Expected b=true, got %v", m["b"])
+	}
+
+	if _, _, err := decodeMsgpack([]byte{0xce, 0x00}); err == nil {
+		t.Error("Expected error for truncated uint32")
+	}
+}
@@ -0,0 +1,102 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"regexp"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+var gitOIDRegex = regexp.MustCompile(`^([0-9a-fA-F]{40}|[0-9a-fA-F]{64})$`)
+
+// GitOIDParser handles Git object IDs (SHA-1 or SHA-256 object names)
+type GitOIDParser struct{}
+
+func (p *GitOIDParser) Name() string {
+	return "GitOID"
+}
+
+func (p *GitOIDParser) CanParse(input string) bool {
+	return gitOIDRegex.MatchString(input)
+}
+
+func (p *GitOIDParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.ToLower(strings.TrimSpace(input))
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid Git object ID format")
+	}
+
+	raw, err := hex.DecodeString(input)
+	if err != nil {
+		return nil, err
+	}
+
+	algorithm := "SHA-1"
+	if len(raw) == 32 {
+		algorithm = "SHA-256"
+	}
+
+	entropy := len(raw) * 8
+
+	return &types.IDInfo{
+		IDType:   fmt.Sprintf("Git Object ID (%s)", algorithm),
+		Standard: input,
+		Size:     len(raw) * 8,
+		Entropy:  &entropy,
+		Hex:      input,
+		Binary:   raw,
+		Extra: map[string]string{
+			"hash_algorithm": algorithm,
+			"object_format":  strings.ToLower(strings.ReplaceAll(algorithm, "-", "")),
+			"abbreviated":    input[:7],
+			"object_header":  "<type> <size>\\0 (blob, tree, commit or tag)",
+			"verification":   "use --git-blob <file> to recompute a blob ID",
+		},
+	}, nil
+}
+
+func (p *GitOIDParser) Generate() (string, error) {
+	// Hash random content as a blob, the way `git hash-object` would
+	content := make([]byte, 32)
+	if _, err := rand.Read(content); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %v", err)
+	}
+	return GitBlobObjectID(content, "sha1"), nil
+}
+
+// GitBlobObjectID computes the Git object ID of blob content using the
+// "sha1" or "sha256" object format, matching `git hash-object`
+func GitBlobObjectID(content []byte, objectFormat string) string {
+	var h hash.Hash
+	if objectFormat == "sha256" {
+		h = sha256.New()
+	} else {
+		h = sha1.New()
+	}
+	fmt.Fprintf(h, "blob %d\x00", len(content))
+	h.Write(content)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// MatchGitBlob reports whether expected is the SHA-1 or SHA-256 blob ID of content, or an
+// abbreviation of one. Like git, it wants at least 4 hex digits; case does not matter.
+func MatchGitBlob(content []byte, expected string) (bool, error) {
+	expected = strings.ToLower(strings.TrimSpace(expected))
+	if len(expected) < 4 {
+		return false, fmt.Errorf("'%s' is too short; give at least 4 hex characters", expected)
+	}
+	if len(expected) > 64 {
+		return false, fmt.Errorf("'%s' is longer than a SHA-256 object ID", expected)
+	}
+	if !hashHexRegex.MatchString(expected) {
+		return false, fmt.Errorf("'%s' is not hexadecimal", expected)
+	}
+	return strings.HasPrefix(GitBlobObjectID(content, "sha1"), expected) ||
+		strings.HasPrefix(GitBlobObjectID(content, "sha256"), expected), nil
+}
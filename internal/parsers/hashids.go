@@ -0,0 +1,408 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Defaults of the reference Hashids implementation
+const (
+	hashidsDefaultAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ1234567890"
+	hashidsDefaultSeps     = "cfhistuCFHISTU"
+	hashidsMinAlphabet     = 16
+	hashidsSepDiv          = 3.5
+	hashidsGuardDiv        = 12
+)
+
+// HashidsSettings configures the salt, alphabet and minimum length of a HashidsParser
+type HashidsSettings struct {
+	Salt      string `json:"salt"`
+	Alphabet  string `json:"alphabet"`   // Empty means the default 62-character alphabet
+	MinLength int    `json:"min_length"` // Shorter hashes are padded with guards and alphabet characters
+}
+
+// hashidsCodec holds the alphabet, separators and guards derived from the settings
+type hashidsCodec struct {
+	salt      []rune
+	alphabet  []rune
+	seps      []rune
+	guards    []rune
+	minLength int
+}
+
+// HashidsParser handles legacy Hashids, the predecessor of Sqids. Hashids depend on a
+// project salt, so the registry's default parser only matches unsalted hashes.
+type HashidsParser struct {
+	settings HashidsSettings
+}
+
+// NewHashidsParser creates a Hashids parser for a project's salt, alphabet and minimum length
+func NewHashidsParser(settings HashidsSettings) (*HashidsParser, error) {
+	p := &HashidsParser{settings: settings}
+	if _, err := p.codec(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// LoadHashidsProfile reads a named profile from a JSON file of the form
+// {"profiles": {"name": {"salt": "...", "alphabet": "...", "min_length": 8}}}
+func LoadHashidsProfile(path, name string) (HashidsSettings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return HashidsSettings{}, fmt.Errorf("failed to read Hashids profiles: %v", err)
+	}
+	var config struct {
+		Profiles map[string]HashidsSettings `json:"profiles"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return HashidsSettings{}, fmt.Errorf("invalid Hashids profiles file: %v", err)
+	}
+	settings, ok := config.Profiles[name]
+	if !ok {
+		return HashidsSettings{}, fmt.Errorf("Hashids profile '%s' not found in %s", name, path)
+	}
+	return settings, nil
+}
+
+// DefaultHashidsConfigPath returns the profiles file in the user's config directory
+func DefaultHashidsConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "idinfo", "hashids.json")
+}
+
+func (p *HashidsParser) Name() string {
+	return "Hashids"
+}
+
+func (p *HashidsParser) CanParse(input string) bool {
+	// All-digit strings are left to the timestamp and Snowflake parsers
+	if len(input) < 2 || isDigits(input) {
+		return false
+	}
+	codec, err := p.codec()
+	if err != nil {
+		return false
+	}
+	// Without a checksum, only canonical hashes are claimed: decoding and re-encoding
+	// must give the same string back, which random strings rarely do
+	numbers, err := codec.decode(input)
+	if err != nil {
+		return false
+	}
+	encoded, err := codec.encode(numbers)
+	return err == nil && encoded == input
+}
+
+func (p *HashidsParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	codec, err := p.codec()
+	if err != nil {
+		return nil, err
+	}
+	numbers, err := codec.decode(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Hashids: %v", err)
+	}
+
+	entropy := int(float64(len([]rune(input))) * math.Log2(float64(len(codec.alphabet)+len(codec.seps)+len(codec.guards))))
+	alphabet := "default (a-z, A-Z, 0-9)"
+	if p.settings.Alphabet != "" {
+		alphabet = p.settings.Alphabet
+	}
+	salt := "none"
+	if p.settings.Salt != "" {
+		salt = fmt.Sprintf("%d characters", len([]rune(p.settings.Salt)))
+	}
+
+	extra := map[string]string{
+		"alphabet":   alphabet,
+		"salt":       salt,
+		"min_length": fmt.Sprintf("%d", codec.minLength),
+		"numbers":    fmt.Sprintf("%v", numbers),
+		"reversible": "Yes (to number array)",
+		"format":     "Hashids (Sqids predecessor)",
+	}
+
+	// Hashids only accept the exact string the encoder produced
+	canonical, err := codec.encode(numbers)
+	if err == nil {
+		extra["canonical"] = canonical
+		if canonical != input {
+			extra["is_canonical"] = "No"
+		} else {
+			extra["is_canonical"] = "Yes"
+		}
+	}
+
+	var maxNum uint64
+	for _, num := range numbers {
+		if num > maxNum {
+			maxNum = num
+		}
+	}
+	if maxNum < 1000 {
+		extra["number_range"] = "Small (< 1K)"
+	} else if maxNum < 1000000 {
+		extra["number_range"] = "Medium (< 1M)"
+	} else {
+		extra["number_range"] = "Large (>= 1M)"
+	}
+
+	info := &types.IDInfo{
+		IDType:   "Hashids",
+		Standard: input,
+		Size:     len(input) * 6, // Rough estimation based on ~6 bits per character
+		Entropy:  &entropy,
+		Hex:      fmt.Sprintf("%x", []byte(input)),
+		Binary:   []byte(input),
+		Extra:    extra,
+	}
+	if len(numbers) == 1 {
+		integer := fmt.Sprintf("%d", numbers[0])
+		info.Integer = &integer
+	}
+	return info, nil
+}
+
+func (p *HashidsParser) Generate() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random number: %v", err)
+	}
+	return p.Encode([]uint64{n.Uint64()})
+}
+
+// Encode encodes numbers with the parser's salt, alphabet and minimum length
+func (p *HashidsParser) Encode(numbers []uint64) (string, error) {
+	codec, err := p.codec()
+	if err != nil {
+		return "", err
+	}
+	return codec.encode(numbers)
+}
+
+// codec derives the working alphabet, separators and guards as the reference implementation does
+func (p *HashidsParser) codec() (*hashidsCodec, error) {
+	alphabetInput := p.settings.Alphabet
+	if alphabetInput == "" {
+		alphabetInput = hashidsDefaultAlphabet
+	}
+	if p.settings.MinLength < 0 {
+		return nil, fmt.Errorf("Hashids min length cannot be negative")
+	}
+
+	var alphabet []rune
+	seen := map[rune]bool{}
+	for _, c := range alphabetInput {
+		if c == ' ' {
+			return nil, fmt.Errorf("Hashids alphabet cannot contain spaces")
+		}
+		if !seen[c] {
+			seen[c] = true
+			alphabet = append(alphabet, c)
+		}
+	}
+	if len(alphabet) < hashidsMinAlphabet {
+		return nil, fmt.Errorf("Hashids alphabet must contain at least %d unique characters", hashidsMinAlphabet)
+	}
+
+	// Separators are the default separators present in the alphabet, removed from it
+	var seps []rune
+	for _, c := range hashidsDefaultSeps {
+		if seen[c] {
+			seps = append(seps, c)
+		}
+	}
+	var rest []rune
+	for _, c := range alphabet {
+		if !strings.ContainsRune(string(seps), c) {
+			rest = append(rest, c)
+		}
+	}
+	alphabet = rest
+
+	salt := []rune(p.settings.Salt)
+	hashidsShuffle(seps, salt)
+	if len(seps) == 0 || float64(len(alphabet))/float64(len(seps)) > hashidsSepDiv {
+		sepsLength := int(math.Ceil(float64(len(alphabet)) / hashidsSepDiv))
+		if sepsLength == 1 {
+			sepsLength = 2
+		}
+		if sepsLength > len(seps) {
+			diff := sepsLength - len(seps)
+			seps = append(seps, alphabet[:diff]...)
+			alphabet = alphabet[diff:]
+		} else {
+			seps = seps[:sepsLength]
+		}
+	}
+	hashidsShuffle(alphabet, salt)
+
+	guardCount := int(math.Ceil(float64(len(alphabet)) / hashidsGuardDiv))
+	var guards []rune
+	if len(alphabet) < 3 {
+		guards = append(guards, seps[:guardCount]...)
+		seps = seps[guardCount:]
+	} else {
+		guards = append(guards, alphabet[:guardCount]...)
+		alphabet = alphabet[guardCount:]
+	}
+
+	return &hashidsCodec{salt: salt, alphabet: alphabet, seps: seps, guards: guards, minLength: p.settings.MinLength}, nil
+}
+
+func (c *hashidsCodec) encode(numbers []uint64) (string, error) {
+	if len(numbers) == 0 {
+		return "", fmt.Errorf("no numbers to encode")
+	}
+
+	alphabet := append([]rune(nil), c.alphabet...)
+	var numbersHash uint64
+	for i, n := range numbers {
+		numbersHash += n % uint64(i+100)
+	}
+
+	lottery := alphabet[numbersHash%uint64(len(alphabet))]
+	result := []rune{lottery}
+	for i, n := range numbers {
+		buffer := append(append([]rune{lottery}, c.salt...), alphabet...)
+		hashidsShuffle(alphabet, buffer[:len(alphabet)])
+		last := hashidsHash(n, alphabet)
+		result = append(result, last...)
+		if i+1 < len(numbers) {
+			n %= uint64(last[0]) + uint64(i)
+			result = append(result, c.seps[n%uint64(len(c.seps))])
+		}
+	}
+
+	if len(result) < c.minLength {
+		guardIndex := (numbersHash + uint64(result[0])) % uint64(len(c.guards))
+		result = append([]rune{c.guards[guardIndex]}, result...)
+		if len(result) < c.minLength {
+			guardIndex = (numbersHash + uint64(result[2])) % uint64(len(c.guards))
+			result = append(result, c.guards[guardIndex])
+		}
+	}
+
+	half := len(alphabet) / 2
+	for len(result) < c.minLength {
+		hashidsShuffle(alphabet, append([]rune(nil), alphabet...))
+		padded := append(append(append([]rune(nil), alphabet[half:]...), result...), alphabet[:half]...)
+		result = padded
+		if excess := len(result) - c.minLength; excess > 0 {
+			result = result[excess/2 : excess/2+c.minLength]
+		}
+	}
+	return string(result), nil
+}
+
+func (c *hashidsCodec) decode(hash string) ([]uint64, error) {
+	if hash == "" {
+		return nil, fmt.Errorf("empty hash")
+	}
+
+	// Guards mark the padding added for the minimum length; the hash sits between them
+	breakdown := hashidsGuardSplit(hash, c.guards)
+
+	runes := []rune(breakdown)
+	if len(runes) < 2 {
+		return nil, fmt.Errorf("hash too short")
+	}
+	lottery := runes[0]
+	alphabet := append([]rune(nil), c.alphabet...)
+	var numbers []uint64
+	for _, sub := range strings.FieldsFunc(string(runes[1:]), func(r rune) bool { return strings.ContainsRune(string(c.seps), r) }) {
+		buffer := append(append([]rune{lottery}, c.salt...), alphabet...)
+		hashidsShuffle(alphabet, buffer[:len(alphabet)])
+		n, err := hashidsUnhash([]rune(sub), alphabet)
+		if err != nil {
+			return nil, err
+		}
+		numbers = append(numbers, n)
+	}
+	if len(numbers) == 0 {
+		return nil, fmt.Errorf("no numbers found")
+	}
+	return numbers, nil
+}
+
+// hashidsGuardSplit mirrors the reference decoder: split on guards and take the middle part
+// when there are two or three parts, otherwise the whole string
+func hashidsGuardSplit(hash string, guards []rune) string {
+	parts := strings.Split(strings.Map(func(r rune) rune {
+		if strings.ContainsRune(string(guards), r) {
+			return ' '
+		}
+		return r
+	}, hash), " ")
+	if len(parts) == 2 || len(parts) == 3 {
+		return parts[1]
+	}
+	return parts[0]
+}
+
+// hashidsShuffle is the consistent shuffle used by Hashids, keyed by the salt
+func hashidsShuffle(alphabet, salt []rune) {
+	if len(salt) == 0 {
+		return
+	}
+	for i, v, p := len(alphabet)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		n := int(salt[v])
+		p += n
+		j := (n + v + p) % i
+		alphabet[i], alphabet[j] = alphabet[j], alphabet[i]
+	}
+}
+
+// hashidsHash writes a number in the base of the shuffled alphabet
+func hashidsHash(n uint64, alphabet []rune) []rune {
+	base := uint64(len(alphabet))
+	var hash []rune
+	for {
+		hash = append([]rune{alphabet[n%base]}, hash...)
+		n /= base
+		if n == 0 {
+			return hash
+		}
+	}
+}
+
+// hashidsUnhash reads a number written in the base of the shuffled alphabet
+func hashidsUnhash(hash, alphabet []rune) (uint64, error) {
+	base := uint64(len(alphabet))
+	var n uint64
+	for _, c := range hash {
+		idx := hashidsIndex(alphabet, c)
+		if idx < 0 {
+			return 0, fmt.Errorf("invalid character %q", c)
+		}
+		pos := uint64(idx)
+		if n > (math.MaxUint64-pos)/base {
+			return 0, fmt.Errorf("number overflows 64 bits")
+		}
+		n = n*base + pos
+	}
+	return n, nil
+}
+
+func hashidsIndex(alphabet []rune, c rune) int {
+	for i, r := range alphabet {
+		if r == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,118 @@
+package parsers
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+)
+
+func TestHashidsParser_ReferenceVectors(t *testing.T) {
+	tests := []struct {
+		settings HashidsSettings
+		numbers  []uint64
+		hash     string
+	}{
+		{HashidsSettings{}, []uint64{1}, "jR"},
+		{HashidsSettings{}, []uint64{1, 2, 3}, "o2fXhV"},
+		{HashidsSettings{Salt: "this is my salt"}, []uint64{12345}, "NkK9"},
+		{HashidsSettings{Salt: "this is my salt"}, []uint64{683, 94108, 123, 5}, "aBMswoO2UB3Sj"},
+		{HashidsSettings{Salt: "this is my salt", MinLength: 8}, []uint64{1}, "gB0NV05e"},
+	}
+
+	for _, tt := range tests {
+		parser, err := NewHashidsParser(tt.settings)
+		if err != nil {
+			t.Fatalf("NewHashidsParser failed: %v", err)
+		}
+		encoded, err := parser.Encode(tt.numbers)
+		if err != nil || encoded != tt.hash {
+			t.Errorf("Encode(%v) = %s, %v; expected %s", tt.numbers, encoded, err, tt.hash)
+		}
+		if !parser.CanParse(tt.hash) {
+			t.Errorf("Expected to detect %s", tt.hash)
+		}
+		info, err := parser.Parse(tt.hash)
+		if err != nil {
+			t.Errorf("Failed to parse %s: %v", tt.hash, err)
+			continue
+		}
+		if info.IDType != "Hashids" || info.Extra["is_canonical"] != "Yes" {
+			t.Errorf("%s: unexpected result %s / %s", tt.hash, info.IDType, info.Extra["is_canonical"])
+		}
+		if len(tt.numbers) == 1 && (info.Integer == nil || *info.Integer != strconv.FormatUint(tt.numbers[0], 10)) {
+			t.Errorf("%s: expected integer %d", tt.hash, tt.numbers[0])
+		}
+	}
+}
+
+func TestHashidsParser_SaltMatters(t *testing.T) {
+	salted, _ := NewHashidsParser(HashidsSettings{Salt: "this is my salt"})
+	unsalted := &HashidsParser{}
+
+	if unsalted.CanParse("aBMswoO2UB3Sj") {
+		t.Error("Expected the unsalted parser to reject a salted hash")
+	}
+	if !salted.CanParse("aBMswoO2UB3Sj") {
+		t.Error("Expected the salted parser to accept its own hash")
+	}
+
+	// Words, NanoIDs and numbers are not canonical Hashids
+	for _, input := range []string{"hello", "V1StGXR8_Z5jdHi6B-myT", "86Rf07xd4zHH", "1234567890"} {
+		if unsalted.CanParse(input) {
+			t.Errorf("Expected to reject %s", input)
+		}
+	}
+
+	// Forced parsing of a non-canonical hash decodes but reports it
+	info, err := salted.Parse("NkK8")
+	if err == nil && info.Extra["is_canonical"] != "No" {
+		t.Errorf("Expected NkK8 to be flagged as non-canonical, got %s", info.Extra["is_canonical"])
+	}
+}
+
+func TestHashidsParser_Settings(t *testing.T) {
+	if _, err := NewHashidsParser(HashidsSettings{Alphabet: "abc"}); err == nil {
+		t.Error("Expected error for an alphabet shorter than 16 characters")
+	}
+	if _, err := NewHashidsParser(HashidsSettings{Alphabet: "abcdefghij klmnopqrstu"}); err == nil {
+		t.Error("Expected error for an alphabet with spaces")
+	}
+
+	custom, err := NewHashidsParser(HashidsSettings{Salt: "pepper", Alphabet: "0123456789abcdef", MinLength: 10})
+	if err != nil {
+		t.Fatalf("NewHashidsParser failed: %v", err)
+	}
+	encoded, err := custom.Encode([]uint64{42, 7})
+	if err != nil || len(encoded) < 10 {
+		t.Fatalf("Expected a hash of at least 10 characters, got %s (%v)", encoded, err)
+	}
+	info, err := custom.Parse(encoded)
+	if err != nil || info.Extra["numbers"] != "[42 7]" {
+		t.Errorf("Expected round trip to [42 7], got %v (%v)", info, err)
+	}
+
+	generated, err := custom.Generate()
+	if err != nil || !custom.CanParse(generated) {
+		t.Errorf("Generated hash %s is not valid: %v", generated, err)
+	}
+}
+
+func TestLoadHashidsProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashids.json")
+	config := `{"profiles": {"legacy": {"salt": "this is my salt", "min_length": 8}}}`
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := LoadHashidsProfile(path, "legacy")
+	if err != nil {
+		t.Fatalf("LoadHashidsProfile failed: %v", err)
+	}
+	if settings.Salt != "this is my salt" || settings.MinLength != 8 {
+		t.Errorf("Unexpected settings %+v", settings)
+	}
+	if _, err := LoadHashidsProfile(path, "missing"); err == nil {
+		t.Error("Expected error for a missing profile")
+	}
+}
@@ -0,0 +1,222 @@
+package parsers
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+var (
+	relativeAgoRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]+)\s+ago$`)
+	relativeInRegex  = regexp.MustCompile(`^(?:in\s+|\+)(\d+(?:\.\d+)?)\s*([a-z]+)$`)
+	relativeNegRegex = regexp.MustCompile(`^-(\d+(?:\.\d+)?)\s*([a-z]+)$`)
+	dayKeywordRegex  = regexp.MustCompile(`^(today|yesterday|tomorrow)(?:\s+(\d{1,2}):(\d{2})(?::(\d{2}))?)?$`)
+	epochNumberRegex = regexp.MustCompile(`^@?(-?\d+)(?:\.(\d+))?$`)
+	isoWeekRegex     = regexp.MustCompile(`^(\d{4})-?w(\d{2})(?:-?([1-7]))?$`)
+)
+
+// absoluteTimeLayouts are tried in order; values without an offset are read in the requested zone
+var absoluteTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// ParseTimeZone resolves a --tz value: an IANA name such as Europe/Berlin, "UTC" or "local"
+func ParseTimeZone(name string) (*time.Location, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "utc", "z":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+	loc, err := time.LoadLocation(strings.TrimSpace(name))
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone '%s' (use an IANA name such as Europe/Berlin, UTC or local)", name)
+	}
+	return loc, nil
+}
+
+// ParseHumanTime parses a time given as RFC3339 or YYYY-MM-DD[ HH:MM[:SS]], "now", "today",
+// "yesterday 14:00", a relative offset ("2h ago", "in 30m", "-3d"), a Unix epoch in seconds,
+// milliseconds, microseconds or nanoseconds (by magnitude, optionally prefixed with @) or an ISO
+// week ("2024-W09", "2024-W09-3"). Values without an offset are read in loc.
+func ParseHumanTime(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	if lower == "now" {
+		return now, nil
+	}
+	if m := dayKeywordRegex.FindStringSubmatch(lower); m != nil {
+		y, mo, d := now.In(loc).Date()
+		day := time.Date(y, mo, d, 0, 0, 0, 0, loc)
+		switch m[1] {
+		case "yesterday":
+			day = day.AddDate(0, 0, -1)
+		case "tomorrow":
+			day = day.AddDate(0, 0, 1)
+		}
+		if m[2] == "" {
+			return day, nil
+		}
+		hour, _ := strconv.Atoi(m[2])
+		minute, _ := strconv.Atoi(m[3])
+		second := 0
+		if m[4] != "" {
+			second, _ = strconv.Atoi(m[4])
+		}
+		if hour > 23 || minute > 59 || second > 59 {
+			return time.Time{}, fmt.Errorf("invalid time of day in '%s'", s)
+		}
+		y, mo, d = day.Date()
+		return time.Date(y, mo, d, hour, minute, second, 0, loc), nil
+	}
+	if m := relativeAgoRegex.FindStringSubmatch(lower); m != nil {
+		return relativeTime(now, -1, m[1], m[2], s)
+	}
+	if m := relativeNegRegex.FindStringSubmatch(lower); m != nil {
+		return relativeTime(now, -1, m[1], m[2], s)
+	}
+	if m := relativeInRegex.FindStringSubmatch(lower); m != nil {
+		return relativeTime(now, 1, m[1], m[2], s)
+	}
+	if m := epochNumberRegex.FindStringSubmatch(lower); m != nil {
+		return epochTime(m[1], m[2], s)
+	}
+	if m := isoWeekRegex.FindStringSubmatch(lower); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		weekday := 1
+		if m[3] != "" {
+			weekday, _ = strconv.Atoi(m[3])
+		}
+		// Week 1 is the week holding January 4th; week 53 only exists in some years
+		_, lastWeek := time.Date(year, 12, 28, 0, 0, 0, 0, loc).ISOWeek()
+		if week < 1 || week > lastWeek {
+			return time.Time{}, fmt.Errorf("%d has no ISO week %d", year, week)
+		}
+		jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, loc)
+		monday := jan4.AddDate(0, 0, -((int(jan4.Weekday()) + 6) % 7))
+		return monday.AddDate(0, 0, (week-1)*7+weekday-1), nil
+	}
+	for _, layout := range absoluteTimeLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s' (use RFC3339, YYYY-MM-DD, 2h ago, yesterday 14:00, an epoch number or 2024-W09)", s)
+}
+
+// relativeTime moves now by a number of units; months and years follow the calendar
+func relativeTime(now time.Time, sign int, number, unit, input string) (time.Time, error) {
+	n, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid offset in '%s'", input)
+	}
+	seconds, ok := collisionUnits[unit]
+	if !ok {
+		unit = strings.TrimSuffix(unit, "s")
+		seconds, ok = collisionUnits[unit]
+	}
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown unit '%s' in '%s' (use s, m, h, d, w, mo or y)", unit, input)
+	}
+	if n == math.Trunc(n) {
+		switch seconds {
+		case secondsPerYear / 12:
+			return now.AddDate(0, sign*int(n), 0), nil
+		case secondsPerYear:
+			return now.AddDate(sign*int(n), 0, 0), nil
+		}
+	}
+	return now.Add(time.Duration(float64(sign) * n * seconds * float64(time.Second))), nil
+}
+
+// epochTime reads a Unix timestamp, choosing its unit by the number of integer digits
+func epochTime(integer, fraction, input string) (time.Time, error) {
+	n, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("epoch '%s' is out of range", input)
+	}
+	var t time.Time
+	var unit time.Duration
+	switch digits := len(strings.TrimPrefix(integer, "-")); {
+	case digits <= 11:
+		t, unit = time.Unix(n, 0), time.Second
+	case digits <= 14:
+		t, unit = time.UnixMilli(n), time.Millisecond
+	case digits <= 17:
+		t, unit = time.UnixMicro(n), time.Microsecond
+	default:
+		t, unit = time.Unix(0, n), time.Nanosecond
+	}
+	if fraction != "" {
+		f, _ := strconv.ParseFloat("0."+fraction, 64)
+		offset := time.Duration(math.Round(f * float64(unit)))
+		if strings.HasPrefix(integer, "-") {
+			offset = -offset
+		}
+		t = t.Add(offset)
+	}
+	return t.UTC(), nil
+}
+
+// FormatAge describes how long before or after now t is, such as "3 days ago" or "in 2 hours"
+func FormatAge(t, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	seconds := d.Seconds()
+	value, unit := seconds/secondsPerYear, "year"
+	switch {
+	case seconds < 1:
+		return "now"
+	case seconds < 59.5:
+		value, unit = seconds, "second"
+	case seconds < 59.5*60:
+		value, unit = seconds/60, "minute"
+	case seconds < 36*3600:
+		value, unit = seconds/3600, "hour"
+	case seconds < 45*86400:
+		value, unit = seconds/86400, "day"
+	case seconds < 18*secondsPerYear/12:
+		value, unit = seconds/(secondsPerYear/12), "month"
+	}
+	n := int64(math.Round(value))
+	if n != 1 {
+		unit += "s"
+	}
+	if future {
+		return fmt.Sprintf("in %d %s", n, unit)
+	}
+	return fmt.Sprintf("%d %s ago", n, unit)
+}
+
+// normalizeTimes converts the timestamps of a parse result and the IDs nested in it to UTC, so
+// every parser reports the same location whatever time.Unix defaulted to
+func normalizeTimes(info *types.IDInfo) {
+	if info == nil {
+		return
+	}
+	if info.DateTime != nil {
+		t := info.DateTime.UTC()
+		info.DateTime = &t
+	}
+	for _, nested := range info.Nested {
+		normalizeTimes(nested.Info)
+	}
+}
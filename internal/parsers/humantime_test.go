@@ -0,0 +1,87 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseHumanTime(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC) // a Wednesday
+	berlin := time.FixedZone("CET", 3600)
+
+	tests := []struct {
+		input    string
+		loc      *time.Location
+		expected time.Time
+	}{
+		{"now", time.UTC, now},
+		{"2h ago", time.UTC, now.Add(-2 * time.Hour)},
+		{"90 minutes ago", time.UTC, now.Add(-90 * time.Minute)},
+		{"in 30m", time.UTC, now.Add(30 * time.Minute)},
+		{"+1.5h", time.UTC, now.Add(90 * time.Minute)},
+		{"-3d", time.UTC, now.AddDate(0, 0, -3)},
+		{"1 month ago", time.UTC, time.Date(2024, 2, 6, 15, 30, 0, 0, time.UTC)},
+		{"today", time.UTC, time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC)},
+		{"yesterday 14:00", time.UTC, time.Date(2024, 3, 5, 14, 0, 0, 0, time.UTC)},
+		{"Yesterday 14:00", berlin, time.Date(2024, 3, 5, 13, 0, 0, 0, time.UTC)},
+		{"tomorrow 08:15:30", time.UTC, time.Date(2024, 3, 7, 8, 15, 30, 0, time.UTC)},
+		{"1700000000", time.UTC, time.Unix(1700000000, 0)},
+		{"@1700000000.25", time.UTC, time.Unix(1700000000, 250000000)},
+		{"1700000000123", time.UTC, time.UnixMilli(1700000000123)},
+		{"1700000000123456", time.UTC, time.UnixMicro(1700000000123456)},
+		{"1700000000123456789", time.UTC, time.Unix(0, 1700000000123456789)},
+		{"2024-W09", time.UTC, time.Date(2024, 2, 26, 0, 0, 0, 0, time.UTC)},
+		{"2024w01-3", time.UTC, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2021-W01", time.UTC, time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)},
+		{"2020-W53-7", time.UTC, time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"2024-03-01", berlin, time.Date(2024, 2, 29, 23, 0, 0, 0, time.UTC)},
+		{"2024-03-01 10:30", time.UTC, time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)},
+		{"2024-03-01T10:30:00+02:00", berlin, time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := ParseHumanTime(tt.input, now, tt.loc)
+		if err != nil {
+			t.Errorf("ParseHumanTime(%q) failed: %v", tt.input, err)
+			continue
+		}
+		if !got.Equal(tt.expected) {
+			t.Errorf("ParseHumanTime(%q) = %s, expected %s", tt.input, got.UTC(), tt.expected.UTC())
+		}
+	}
+
+	for _, input := range []string{"", "yesterday-ish", "2021-W53", "today 25:00", "3 fortnights ago"} {
+		if _, err := ParseHumanTime(input, now, time.UTC); err == nil {
+			t.Errorf("Expected an error for %q", input)
+		}
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	now := time.Date(2024, 3, 6, 15, 30, 0, 0, time.UTC)
+	tests := map[time.Duration]string{
+		0:                        "now",
+		-45 * time.Second:        "45 seconds ago",
+		-time.Hour:               "1 hour ago",
+		2 * time.Hour:            "in 2 hours",
+		-3 * 24 * time.Hour:      "3 days ago",
+		-90 * 24 * time.Hour:     "3 months ago",
+		5 * 365 * 24 * time.Hour: "in 5 years",
+	}
+	for offset, expected := range tests {
+		if got := FormatAge(now.Add(offset), now); got != expected {
+			t.Errorf("FormatAge(%s) = %q, expected %q", offset, got, expected)
+		}
+	}
+}
+
+func TestParseTimeZone(t *testing.T) {
+	if loc, err := ParseTimeZone("utc"); err != nil || loc != time.UTC {
+		t.Errorf("Expected UTC, got %v, %v", loc, err)
+	}
+	if loc, err := ParseTimeZone("local"); err != nil || loc != time.Local {
+		t.Errorf("Expected the local zone, got %v, %v", loc, err)
+	}
+	if _, err := ParseTimeZone("Mars/Olympus_Mons"); err == nil {
+		t.Error("Expected an error for an unknown zone")
+	}
+}
@@ -0,0 +1,180 @@
+package parsers
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// ibanCountry describes a country's IBAN length and where the bank and branch codes sit in the BBAN
+type ibanCountry struct {
+	name         string
+	length       int
+	bankOffset   int
+	bankLength   int
+	branchOffset int
+	branchLength int
+}
+
+// IBAN registry entries for commonly used countries
+var ibanCountries = map[string]ibanCountry{
+	"AD": {"Andorra", 24, 0, 4, 4, 4},
+	"AE": {"United Arab Emirates", 23, 0, 3, 0, 0},
+	"AT": {"Austria", 20, 0, 5, 0, 0},
+	"BE": {"Belgium", 16, 0, 3, 0, 0},
+	"BG": {"Bulgaria", 22, 0, 4, 4, 4},
+	"BH": {"Bahrain", 22, 0, 4, 0, 0},
+	"BR": {"Brazil", 29, 0, 8, 8, 5},
+	"CH": {"Switzerland", 21, 0, 5, 0, 0},
+	"CY": {"Cyprus", 28, 0, 3, 3, 5},
+	"CZ": {"Czech Republic", 24, 0, 4, 0, 0},
+	"DE": {"Germany", 22, 0, 8, 0, 0},
+	"DK": {"Denmark", 18, 0, 4, 0, 0},
+	"EE": {"Estonia", 20, 0, 2, 0, 0},
+	"ES": {"Spain", 24, 0, 4, 4, 4},
+	"FI": {"Finland", 18, 0, 3, 0, 0},
+	"FR": {"France", 27, 0, 5, 5, 5},
+	"GB": {"United Kingdom", 22, 0, 4, 4, 6},
+	"GI": {"Gibraltar", 23, 0, 4, 0, 0},
+	"GR": {"Greece", 27, 0, 3, 3, 4},
+	"HR": {"Croatia", 21, 0, 7, 0, 0},
+	"HU": {"Hungary", 28, 0, 3, 3, 4},
+	"IE": {"Ireland", 22, 0, 4, 4, 6},
+	"IL": {"Israel", 23, 0, 3, 3, 3},
+	"IS": {"Iceland", 26, 0, 4, 0, 0},
+	"IT": {"Italy", 27, 1, 5, 6, 5},
+	"KW": {"Kuwait", 30, 0, 4, 0, 0},
+	"KZ": {"Kazakhstan", 20, 0, 3, 0, 0},
+	"LB": {"Lebanon", 28, 0, 4, 0, 0},
+	"LI": {"Liechtenstein", 21, 0, 5, 0, 0},
+	"LT": {"Lithuania", 20, 0, 5, 0, 0},
+	"LU": {"Luxembourg", 20, 0, 3, 0, 0},
+	"LV": {"Latvia", 21, 0, 4, 0, 0},
+	"MC": {"Monaco", 27, 0, 5, 5, 5},
+	"MT": {"Malta", 31, 0, 4, 4, 5},
+	"NL": {"Netherlands", 18, 0, 4, 0, 0},
+	"NO": {"Norway", 15, 0, 4, 0, 0},
+	"PL": {"Poland", 28, 0, 3, 3, 4},
+	"PT": {"Portugal", 25, 0, 4, 4, 4},
+	"QA": {"Qatar", 29, 0, 4, 0, 0},
+	"RO": {"Romania", 24, 0, 4, 0, 0},
+	"RS": {"Serbia", 22, 0, 3, 0, 0},
+	"SA": {"Saudi Arabia", 24, 0, 2, 0, 0},
+	"SE": {"Sweden", 24, 0, 3, 0, 0},
+	"SI": {"Slovenia", 19, 0, 2, 2, 3},
+	"SK": {"Slovakia", 24, 0, 4, 0, 0},
+	"SM": {"San Marino", 27, 1, 5, 6, 5},
+	"TR": {"Turkey", 26, 0, 5, 0, 0},
+	"UA": {"Ukraine", 29, 0, 6, 0, 0},
+}
+
+var ibanRegex = regexp.MustCompile(`^[A-Z]{2}\d{2}[A-Z0-9]{11,30}$`)
+
+// IBANParser handles International Bank Account Numbers (ISO 13616) with mod-97 validation
+type IBANParser struct{}
+
+func (p *IBANParser) Name() string {
+	return "IBAN"
+}
+
+func (p *IBANParser) CanParse(input string) bool {
+	iban := strings.ToUpper(stripSeparators(input))
+	if !ibanRegex.MatchString(iban) {
+		return false
+	}
+	country, known := ibanCountries[iban[:2]]
+	return known && len(iban) == country.length && ibanMod97(iban) == 1
+}
+
+func (p *IBANParser) Parse(input string) (*types.IDInfo, error) {
+	iban := strings.ToUpper(stripSeparators(strings.TrimSpace(input)))
+	if !ibanRegex.MatchString(iban) {
+		return nil, fmt.Errorf("invalid IBAN format")
+	}
+	country, known := ibanCountries[iban[:2]]
+	if !known {
+		return nil, fmt.Errorf("unsupported IBAN country code %s", iban[:2])
+	}
+	if len(iban) != country.length {
+		return nil, fmt.Errorf("%s IBANs have %d characters, got %d", country.name, country.length, len(iban))
+	}
+	if ibanMod97(iban) != 1 {
+		return nil, fmt.Errorf("IBAN check digits do not validate (mod 97)")
+	}
+
+	bban := iban[4:]
+	extra := map[string]string{
+		"country":      fmt.Sprintf("%s (%s)", iban[:2], country.name),
+		"check_digits": iban[2:4],
+		"bban":         bban,
+		"checksum":     "valid (ISO 7064 mod 97-10)",
+	}
+
+	info := &types.IDInfo{
+		IDType:   "IBAN",
+		Version:  country.name,
+		Standard: formatIBAN(iban),
+		Size:     len(iban) * 8,
+		Hex:      fmt.Sprintf("%x", iban),
+		Binary:   []byte(iban),
+		Extra:    extra,
+	}
+
+	bank := bban[country.bankOffset : country.bankOffset+country.bankLength]
+	extra["bank_code"] = bank
+	info.Node1 = &bank
+	accountStart := country.bankOffset + country.bankLength
+	if country.branchLength > 0 {
+		branch := bban[country.branchOffset : country.branchOffset+country.branchLength]
+		extra["branch_code"] = branch
+		info.Node2 = &branch
+		accountStart = country.branchOffset + country.branchLength
+	}
+	extra["account_number"] = bban[accountStart:]
+
+	return info, nil
+}
+
+func (p *IBANParser) Generate() (string, error) {
+	// Generate a German IBAN: 8-digit bank code followed by a 10-digit account number
+	bban, err := randomDigits(18)
+	if err != nil {
+		return "", err
+	}
+	check := 98 - ibanMod97("DE00"+bban)
+	return fmt.Sprintf("DE%02d%s", check, bban), nil
+}
+
+// ibanMod97 moves the first four characters to the end, maps letters to 10-35 and returns the value mod 97
+func ibanMod97(iban string) int {
+	rearranged := iban[4:] + iban[:4]
+	var sb strings.Builder
+	for _, c := range rearranged {
+		if c >= 'A' && c <= 'Z' {
+			sb.WriteString(fmt.Sprintf("%d", c-'A'+10))
+		} else {
+			sb.WriteRune(c)
+		}
+	}
+	n, ok := new(big.Int).SetString(sb.String(), 10)
+	if !ok {
+		return -1
+	}
+	return int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
+
+// formatIBAN groups an IBAN into blocks of four characters, as printed on statements
+func formatIBAN(iban string) string {
+	var groups []string
+	for i := 0; i < len(iban); i += 4 {
+		end := i + 4
+		if end > len(iban) {
+			end = len(iban)
+		}
+		groups = append(groups, iban[i:end])
+	}
+	return strings.Join(groups, " ")
+}
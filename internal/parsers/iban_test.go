@@ -0,0 +1,67 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestIBANParser(t *testing.T) {
+	parser := &IBANParser{}
+
+	tests := []struct {
+		input    string
+		standard string
+		country  string
+		bank     string
+		branch   string
+	}{
+		{"DE89370400440532013000", "DE89 3704 0044 0532 0130 00", "Germany", "37040044", ""},
+		{"GB82 WEST 1234 5698 7654 32", "GB82 WEST 1234 5698 7654 32", "United Kingdom", "WEST", "123456"},
+		{"fr1420041010050500013m02606", "FR14 2004 1010 0505 0001 3M02 606", "France", "20041", "01005"},
+		{"NL91ABNA0417164300", "NL91 ABNA 0417 1643 00", "Netherlands", "ABNA", ""},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Standard != test.standard {
+			t.Errorf("%s: expected %s, got %s", test.input, test.standard, info.Standard)
+		}
+		if info.Version != test.country {
+			t.Errorf("%s: expected country %s, got %s", test.input, test.country, info.Version)
+		}
+		if info.Extra["bank_code"] != test.bank {
+			t.Errorf("%s: expected bank code %s, got %s", test.input, test.bank, info.Extra["bank_code"])
+		}
+		if info.Extra["branch_code"] != test.branch {
+			t.Errorf("%s: expected branch code %q, got %q", test.input, test.branch, info.Extra["branch_code"])
+		}
+	}
+
+	invalid := []string{
+		"",
+		"DE88370400440532013000",   // Wrong check digits
+		"DE8937040044053201300",    // Wrong length
+		"XX89370400440532013000",   // Unknown country
+		"01HVZ7JKJJ8M9K9M9M9M9M9M", // ULID
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated IBAN is not valid: %s", generated)
+	}
+}
@@ -0,0 +1,105 @@
+package parsers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Reporting Body Identifiers: the first two digits of the Type Allocation Code
+var imeiReportingBodies = map[string]string{
+	"00": "Test IMEI",
+	"01": "PTCRB (United States)",
+	"10": "DECT (Europe)",
+	"30": "Iridium (United States)",
+	"33": "DGPT (France)",
+	"35": "BABT (United Kingdom)",
+	"44": "BABT (United Kingdom)",
+	"45": "NTA (Denmark)",
+	"49": "BZT / BAPT (Germany)",
+	"50": "BZT ETS (Germany)",
+	"51": "Cetecom ICT (Germany)",
+	"52": "Cetecom (Germany)",
+	"53": "TÜV (Germany)",
+	"54": "Phoenix Test Lab (Germany)",
+	"86": "TAF (China)",
+	"91": "MSAI (India)",
+	"98": "BABT (United Kingdom)",
+	"99": "GHA (Global Hexadecimal Administrator)",
+}
+
+// IMEIParser handles 15-digit IMEIs (Luhn check digit) and 16-digit IMEISVs
+type IMEIParser struct{}
+
+func (p *IMEIParser) Name() string {
+	return "IMEI"
+}
+
+func (p *IMEIParser) CanParse(input string) bool {
+	digits := stripSeparators(input)
+	if !isDigits(digits) {
+		return false
+	}
+	if _, known := imeiReportingBodies[digits[:min(2, len(digits))]]; !known {
+		return false
+	}
+	// IMEISVs have no check digit, so only IMEIs are claimed automatically
+	return len(digits) == 15 && luhnValid(digits)
+}
+
+func (p *IMEIParser) Parse(input string) (*types.IDInfo, error) {
+	digits := stripSeparators(strings.TrimSpace(input))
+	if !isDigits(digits) || (len(digits) != 15 && len(digits) != 16) {
+		return nil, fmt.Errorf("IMEIs have 15 digits (16 for IMEISV)")
+	}
+
+	tac, serial := digits[:8], digits[8:14]
+	extra := map[string]string{
+		"tac":           tac,
+		"serial_number": serial,
+	}
+
+	version := "IMEI"
+	if len(digits) == 15 {
+		if !luhnValid(digits) {
+			return nil, fmt.Errorf("invalid IMEI check digit: expected %c", luhnCheckDigit(digits[:14]))
+		}
+		extra["check_digit"] = digits[14:]
+		extra["checksum"] = "valid (Luhn)"
+	} else {
+		version = "IMEISV"
+		extra["software_version"] = digits[14:]
+		extra["imei"] = digits[:14] + string(luhnCheckDigit(digits[:14]))
+	}
+
+	body, known := imeiReportingBodies[digits[:2]]
+	if !known {
+		body = "unknown"
+	}
+	extra["reporting_body"] = fmt.Sprintf("%s (%s)", digits[:2], body)
+
+	hexStr, raw := digitsBinary(digits)
+	info := &types.IDInfo{
+		IDType:   "IMEI",
+		Version:  version,
+		Standard: digits,
+		Integer:  &digits,
+		Size:     len(digits) * 4,
+		Hex:      hexStr,
+		Binary:   raw,
+		Node1:    &tac,
+		Node2:    &body,
+		Extra:    extra,
+	}
+	return info, nil
+}
+
+func (p *IMEIParser) Generate() (string, error) {
+	body, err := randomDigits(12)
+	if err != nil {
+		return "", err
+	}
+	payload := "35" + body
+	return payload + string(luhnCheckDigit(payload)), nil
+}
@@ -0,0 +1,55 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestIMEIParser(t *testing.T) {
+	parser := &IMEIParser{}
+
+	info, err := parser.Parse("49-015420-323751-8")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.Version != "IMEI" || info.Extra["tac"] != "49015420" || info.Extra["serial_number"] != "323751" {
+		t.Errorf("Unexpected IMEI breakdown: %v", info.Extra)
+	}
+	if *info.Node2 != "BZT / BAPT (Germany)" {
+		t.Errorf("Unexpected reporting body: %s", *info.Node2)
+	}
+
+	info, err = parser.Parse("3520990017614823")
+	if err != nil {
+		t.Fatalf("Parse of IMEISV failed: %v", err)
+	}
+	if info.Version != "IMEISV" || info.Extra["software_version"] != "23" {
+		t.Errorf("Unexpected IMEISV breakdown: %v", info.Extra)
+	}
+
+	valid := []string{"490154203237518", "352099001761481"}
+	for _, id := range valid {
+		if !parser.CanParse(id) {
+			t.Errorf("Expected to parse %s", id)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"490154203237519",  // Wrong check digit
+		"720154203237516",  // Unknown reporting body
+		"3520990017614823", // IMEISV has no check digit to validate
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated IMEI is not valid: %s", generated)
+	}
+}
@@ -0,0 +1,163 @@
+package parsers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// ISBN registration groups, longest prefix first within each length
+var isbnGroups = []struct {
+	prefix string
+	name   string
+}{
+	{"979-10", "France"}, {"979-11", "South Korea"}, {"979-12", "Italy"}, {"979-8", "United States"},
+	{"950", "Argentina"}, {"951", "Finland"}, {"952", "Finland"}, {"953", "Croatia"}, {"957", "Taiwan"},
+	{"958", "Colombia"}, {"959", "Cuba"}, {"960", "Greece"}, {"961", "Slovenia"}, {"962", "Hong Kong"},
+	{"963", "Hungary"}, {"964", "Iran"}, {"965", "Israel"}, {"966", "Ukraine"}, {"967", "Malaysia"},
+	{"968", "Mexico"}, {"970", "Mexico"}, {"972", "Portugal"}, {"975", "Turkey"},
+	{"80", "Czech Republic and Slovakia"}, {"81", "India"}, {"82", "Norway"}, {"83", "Poland"},
+	{"84", "Spain"}, {"85", "Brazil"}, {"86", "Former Yugoslavia"}, {"87", "Denmark"}, {"88", "Italy"},
+	{"89", "South Korea"}, {"90", "Netherlands and Flanders"}, {"91", "Sweden"},
+	{"92", "International organizations"}, {"93", "India"}, {"94", "Netherlands"},
+	{"0", "English language"}, {"1", "English language"}, {"2", "French language"}, {"3", "German language"},
+	{"4", "Japan"}, {"5", "Former USSR"}, {"7", "China"},
+}
+
+var (
+	isbn10Regex       = regexp.MustCompile(`^\d{9}[\dX]$`)
+	isbnHyphenedRegex = regexp.MustCompile(`^(?:ISBN(?:-1[03])?:?\s*)?(?:\d+-){3,4}[\dX]$`)
+)
+
+// ISBNParser handles ISBN-10 (mod 11) and ISBN-13 (978/979 EAN) book numbers
+type ISBNParser struct{}
+
+func (p *ISBNParser) Name() string {
+	return "ISBN"
+}
+
+func (p *ISBNParser) CanParse(input string) bool {
+	isbn, hyphenated := normalizeISBN(input)
+	switch len(isbn) {
+	case 13:
+		return isISBN13(isbn)
+	case 10:
+		// A bare 10-digit number is far more likely a Unix timestamp, so only
+		// claim it when it is written like an ISBN or could not be a timestamp
+		if !isISBN10(isbn) {
+			return false
+		}
+		return hyphenated || isbn[0] == '0' || isbn[9] == 'X'
+	}
+	return false
+}
+
+func (p *ISBNParser) Parse(input string) (*types.IDInfo, error) {
+	isbn, _ := normalizeISBN(strings.TrimSpace(input))
+
+	var isbn13, isbn10 string
+	switch {
+	case len(isbn) == 13 && isISBN13(isbn):
+		isbn13 = isbn
+		if strings.HasPrefix(isbn, "978") {
+			isbn10 = isbn[3:12] + string(isbn10CheckDigit(isbn[3:12]))
+		}
+	case len(isbn) == 10 && isISBN10(isbn):
+		isbn10 = isbn
+		isbn13 = "978" + isbn[:9] + string(gs1CheckDigit("978"+isbn[:9]))
+	default:
+		return nil, fmt.Errorf("invalid ISBN or check digit")
+	}
+
+	version := "ISBN-13"
+	if len(isbn) == 10 {
+		version = "ISBN-10"
+	}
+
+	extra := map[string]string{
+		"isbn13":   isbn13,
+		"checksum": "valid",
+	}
+	if isbn10 != "" {
+		extra["isbn10"] = isbn10
+	}
+
+	// Registration groups are looked up on the 979/978-stripped number
+	lookup := isbn13[3:]
+	if strings.HasPrefix(isbn13, "979") {
+		lookup = "979-" + lookup
+	}
+	group := "unknown"
+	for _, g := range isbnGroups {
+		if strings.HasPrefix(lookup, g.prefix) {
+			group = g.name
+			extra["registration_group"] = strings.TrimPrefix(g.prefix, "979-")
+			break
+		}
+	}
+	extra["group_name"] = group
+
+	hexStr, raw := digitsBinary(isbn13)
+	info := &types.IDInfo{
+		IDType:   "ISBN",
+		Version:  version,
+		Standard: isbn,
+		Integer:  &isbn13,
+		Size:     len(isbn13) * 4,
+		Hex:      hexStr,
+		Binary:   raw,
+		Extra:    extra,
+	}
+	prefix := isbn13[:3]
+	info.Node1 = &prefix
+	info.Node2 = &group
+
+	return info, nil
+}
+
+func (p *ISBNParser) Generate() (string, error) {
+	body, err := randomDigits(9)
+	if err != nil {
+		return "", err
+	}
+	payload := "978" + body
+	return payload + string(gs1CheckDigit(payload)), nil
+}
+
+// normalizeISBN strips an "ISBN" label, spaces and hyphens, and reports whether the input was hyphenated
+func normalizeISBN(input string) (string, bool) {
+	hyphenated := isbnHyphenedRegex.MatchString(strings.ToUpper(input))
+	s := strings.ToUpper(input)
+	for _, label := range []string{"ISBN-13:", "ISBN-10:", "ISBN-13", "ISBN-10", "ISBN:", "ISBN"} {
+		if strings.HasPrefix(s, label) {
+			s = s[len(label):]
+			hyphenated = true
+			break
+		}
+	}
+	return stripSeparators(s), hyphenated
+}
+
+func isISBN10(isbn string) bool {
+	return isbn10Regex.MatchString(isbn) && isbn10CheckDigit(isbn[:9]) == isbn[9]
+}
+
+func isISBN13(isbn string) bool {
+	return isDigits(isbn) && (strings.HasPrefix(isbn, "978") || strings.HasPrefix(isbn, "979")) &&
+		gs1CheckDigit(isbn[:12]) == isbn[12]
+}
+
+// isbn10CheckDigit computes the mod-11 check character, where 10 is written as X
+func isbn10CheckDigit(payload string) byte {
+	sum := 0
+	for i := 0; i < 9; i++ {
+		sum += int(payload[i]-'0') * (10 - i)
+	}
+	check := (11 - sum%11) % 11
+	if check == 10 {
+		return 'X'
+	}
+	return byte('0' + check)
+}
@@ -0,0 +1,70 @@
+package parsers
+
+import (
+	"testing"
+)
+
+func TestISBNParser(t *testing.T) {
+	parser := &ISBNParser{}
+
+	tests := []struct {
+		input   string
+		version string
+		isbn13  string
+		isbn10  string
+		group   string
+	}{
+		{"9780306406157", "ISBN-13", "9780306406157", "0306406152", "English language"},
+		{"978-3-16-148410-0", "ISBN-13", "9783161484100", "316148410X", "German language"},
+		{"0-306-40615-2", "ISBN-10", "9780306406157", "0306406152", "English language"},
+		{"ISBN 0-8044-2957-X", "ISBN-10", "9780804429573", "080442957X", "English language"},
+		{"979-10-90636-07-1", "ISBN-13", "9791090636071", "", "France"},
+	}
+
+	for _, test := range tests {
+		if !parser.CanParse(test.input) {
+			t.Errorf("Expected to parse %s", test.input)
+			continue
+		}
+		info, err := parser.Parse(test.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", test.input, err)
+			continue
+		}
+		if info.Version != test.version {
+			t.Errorf("%s: expected %s, got %s", test.input, test.version, info.Version)
+		}
+		if info.Extra["isbn13"] != test.isbn13 || info.Extra["isbn10"] != test.isbn10 {
+			t.Errorf("%s: unexpected conversions %s / %s", test.input, info.Extra["isbn13"], info.Extra["isbn10"])
+		}
+		if info.Extra["group_name"] != test.group {
+			t.Errorf("%s: expected group %s, got %s", test.input, test.group, info.Extra["group_name"])
+		}
+	}
+
+	invalid := []string{
+		"",
+		"9780306406158", // Wrong check digit
+		"9790306406157", // Not a 978/979 checksum
+		"1792327528",    // Bare 10 digits: reads as a Unix timestamp even if mod 11 holds
+		"0306406153",
+	}
+	for _, id := range invalid {
+		if parser.CanParse(id) {
+			t.Errorf("Expected to reject %q", id)
+		}
+	}
+
+	// Forcing the format still accepts a bare ISBN-10 with a valid check digit
+	if _, err := parser.Parse("1861972717"); err != nil {
+		t.Errorf("Expected forced parse of bare ISBN-10 to succeed: %v", err)
+	}
+
+	generated, err := parser.Generate()
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	if !parser.CanParse(generated) {
+		t.Errorf("Generated ISBN is not valid: %s", generated)
+	}
+}
@@ -0,0 +1,128 @@
+package parsers
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// jsonWebKey is the subset of RFC 7517 key parameters needed for verification
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// verificationKey is a decoded JWK: []byte, *rsa.PublicKey, *ecdsa.PublicKey or ed25519.PublicKey
+type verificationKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+// loadJWKS reads a JWK Set (or a single JWK) from a local file
+func loadJWKS(path string) ([]verificationKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS file: %v", err)
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS file: %v", err)
+	}
+	if set.Keys == nil {
+		var single jsonWebKey
+		if err := json.Unmarshal(data, &single); err == nil && single.Kty != "" {
+			set.Keys = []jsonWebKey{single}
+		}
+	}
+	if len(set.Keys) == 0 {
+		return nil, fmt.Errorf("JWKS file %s contains no keys", path)
+	}
+
+	var keys []verificationKey
+	for i, jwk := range set.Keys {
+		key, err := decodeJWK(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS key %d: %v", i, err)
+		}
+		keys = append(keys, verificationKey{kid: jwk.Kid, alg: jwk.Alg, key: key})
+	}
+	return keys, nil
+}
+
+// decodeJWK converts a JWK into a Go key usable by the crypto packages
+func decodeJWK(jwk jsonWebKey) (interface{}, error) {
+	switch jwk.Kty {
+	case "oct":
+		return base64.RawURLEncoding.DecodeString(jwk.K)
+	case "RSA":
+		n, err := decodeJWKInt(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus: %v", err)
+		}
+		e, err := decodeJWKInt(jwk.E)
+		if err != nil || !e.IsInt64() {
+			return nil, fmt.Errorf("invalid RSA exponent")
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch jwk.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported EC curve %q", jwk.Crv)
+		}
+		x, err := decodeJWKInt(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC x coordinate: %v", err)
+		}
+		y, err := decodeJWKInt(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC y coordinate: %v", err)
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported OKP curve %q", jwk.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 public key")
+		}
+		return ed25519.PublicKey(x), nil
+	}
+	return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+}
+
+func decodeJWKInt(s string) (*big.Int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 {
+		return nil, fmt.Errorf("empty value")
+	}
+	return new(big.Int).SetBytes(raw), nil
+}
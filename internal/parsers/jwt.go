@@ -0,0 +1,458 @@
+package parsers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	_ "crypto/sha512" // Registers SHA-384/512 for crypto.Hash
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+var jwtSegmentRegex = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// Registered claims holding NumericDate values
+var jwtTimeClaims = map[string]bool{"iat": true, "nbf": true, "exp": true, "auth_time": true, "updated_at": true}
+
+// Shortest claim value worth running through the registry; shorter values
+// such as roles or scopes are almost always misread as NanoIDs or Sqids
+const jwtMinNestedLength = 10
+
+// JWTSettings configures signature verification of a JWTParser
+type JWTSettings struct {
+	Secret   string // HMAC secret for HS256/384/512
+	JWKSFile string // Local JWK Set with oct, RSA, EC or OKP keys
+}
+
+// JWTParser handles JSON Web Tokens (JWS) and recognizes encrypted JWE tokens
+type JWTParser struct {
+	settings JWTSettings
+	keys     []verificationKey
+	registry *Registry // Parses claims and kids; set by the registry the parser belongs to
+}
+
+// NewJWTParser creates a JWT parser that verifies signatures with the given keys
+func NewJWTParser(settings JWTSettings) (*JWTParser, error) {
+	p := &JWTParser{settings: settings}
+	if settings.Secret != "" {
+		p.keys = append(p.keys, verificationKey{kid: "", key: []byte(settings.Secret)})
+	}
+	if settings.JWKSFile != "" {
+		keys, err := loadJWKS(settings.JWKSFile)
+		if err != nil {
+			return nil, err
+		}
+		p.keys = append(p.keys, keys...)
+	}
+	return p, nil
+}
+
+func (p *JWTParser) Name() string {
+	return "JWT"
+}
+
+func (p *JWTParser) CanParse(input string) bool {
+	segments := strings.Split(input, ".")
+	if len(segments) != 3 && len(segments) != 5 {
+		return false
+	}
+	for _, segment := range segments {
+		if !jwtSegmentRegex.MatchString(segment) {
+			return false
+		}
+	}
+	_, err := decodeJWTHeader(segments[0])
+	return err == nil
+}
+
+func (p *JWTParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("invalid JWT format")
+	}
+
+	segments := strings.Split(input, ".")
+	header, _ := decodeJWTHeader(segments[0])
+	if len(segments) == 5 {
+		return p.parseJWE(input, segments, header)
+	}
+
+	alg, _ := header["alg"].(string)
+	extra := map[string]string{"format": "JWS compact serialization"}
+	for name, value := range header {
+		extra["header."+name] = jwtValueString(value)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(segments[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT signature encoding: %v", err)
+	}
+
+	info := &types.IDInfo{
+		IDType:   "JWT",
+		Standard: input,
+		Size:     len(input) * 8,
+		Hex:      hex.EncodeToString(signature),
+		Binary:   signature,
+		Extra:    extra,
+	}
+
+	claims, err := decodeJWTClaims(segments[1])
+	if err != nil {
+		// Signed, but the payload is not a JSON claims set
+		info.IDType = "JWS (opaque payload)"
+		extra["payload_bytes"] = fmt.Sprintf("%d", len(segments[1])*3/4)
+	} else {
+		p.describeClaims(info, claims)
+	}
+
+	verification := p.verify(alg, header, segments[0]+"."+segments[1], signature)
+	extra["signature"] = verification
+	info.Version = alg + ", " + jwtVersionStatus(verification, extra["validity"])
+
+	if kid, ok := header["kid"].(string); ok {
+		info.Nested = append(info.Nested, p.nested().nestedIDs("kid", kid, "")...)
+	}
+
+	return info, nil
+}
+
+// describeClaims fills in timestamps, issuer, audience and nested IDs from the claims set
+func (p *JWTParser) describeClaims(info *types.IDInfo, claims map[string]interface{}) {
+	names := make([]string, 0, len(claims))
+	for name := range claims {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := time.Now()
+	times := map[string]time.Time{}
+	for _, name := range names {
+		value := jwtValueString(claims[name])
+		info.Extra["claim."+name] = value
+
+		if jwtTimeClaims[name] {
+			if t, ok := jwtNumericDate(claims[name]); ok {
+				times[name] = t
+				info.Extra["claim."+name] = fmt.Sprintf("%s (%s)", value, t.Format(time.RFC3339))
+				info.Nested = append(info.Nested, p.nested().nestedIDs(name, value, "unixtime")...)
+			}
+			continue
+		}
+
+		switch claims[name].(type) {
+		case string, json.Number:
+			if len(value) >= jwtMinNestedLength {
+				info.Nested = append(info.Nested, p.nested().nestedIDs(name, value, "")...)
+			}
+		}
+	}
+
+	if iat, ok := times["iat"]; ok {
+		ts := fmt.Sprintf("%d", iat.Unix())
+		info.DateTime = &iat
+		info.Timestamp = &ts
+	}
+	if iss, ok := claims["iss"].(string); ok {
+		info.Node1 = &iss
+	}
+	if aud, ok := claims["aud"]; ok {
+		audience := jwtValueString(aud)
+		if list, ok := aud.([]interface{}); ok {
+			var parts []string
+			for _, a := range list {
+				parts = append(parts, jwtValueString(a))
+			}
+			audience = strings.Join(parts, ", ")
+		}
+		info.Node2 = &audience
+	}
+
+	validity := "valid"
+	exp, hasExp := times["exp"]
+	nbf, hasNbf := times["nbf"]
+	switch {
+	case hasExp && !now.Before(exp):
+		validity = "expired " + FormatAge(exp, now)
+	case hasNbf && now.Before(nbf):
+		validity = "not yet valid, starts " + FormatAge(nbf, now)
+	case hasExp:
+		validity = "valid, expires " + FormatAge(exp, now)
+	}
+	info.Extra["validity"] = validity
+}
+
+// jwtVersionStatus sums up the signature check and validity in a few words for the Version row,
+// leaving the details to the signature and validity rows
+func jwtVersionStatus(verification, validity string) string {
+	status := "signature valid"
+	switch {
+	case strings.HasPrefix(verification, "unsigned"):
+		status = "unsigned"
+	case strings.HasPrefix(verification, "not verified"):
+		status = "unverified"
+	case strings.HasPrefix(verification, "INVALID"):
+		status = "INVALID signature"
+	}
+	switch {
+	case strings.HasPrefix(validity, "expired"):
+		status += ", expired"
+	case strings.HasPrefix(validity, "not yet valid"):
+		status += ", not yet valid"
+	}
+	return status
+}
+
+// parseJWE describes an encrypted token, whose payload cannot be inspected
+func (p *JWTParser) parseJWE(input string, segments []string, header map[string]interface{}) (*types.IDInfo, error) {
+	alg, _ := header["alg"].(string)
+	enc, _ := header["enc"].(string)
+
+	extra := map[string]string{
+		"format":          "JWE compact serialization",
+		"encrypted_key":   fmt.Sprintf("%d bytes", len(segments[1])*3/4),
+		"iv":              fmt.Sprintf("%d bytes", len(segments[2])*3/4),
+		"ciphertext":      fmt.Sprintf("%d bytes", len(segments[3])*3/4),
+		"note":            "payload is encrypted and cannot be decoded without the recipient's key",
+		"signature":       "not applicable (encrypted)",
+		"authentication":  fmt.Sprintf("%d-byte tag", len(segments[4])*3/4),
+		"key_management":  alg,
+		"content_encrypt": enc,
+	}
+	for name, value := range header {
+		extra["header."+name] = jwtValueString(value)
+	}
+
+	info := &types.IDInfo{
+		IDType:   "JWE (encrypted token)",
+		Version:  fmt.Sprintf("%s / %s", alg, enc),
+		Standard: input,
+		Size:     len(input) * 8,
+		Extra:    extra,
+	}
+	if kid, ok := header["kid"].(string); ok {
+		info.Nested = p.nested().nestedIDs("kid", kid, "")
+	}
+	return info, nil
+}
+
+// verify checks the signature against every configured key that fits the algorithm
+func (p *JWTParser) verify(alg string, header map[string]interface{}, signingInput string, signature []byte) string {
+	if alg == "none" {
+		return "unsigned (alg none)"
+	}
+	if len(p.keys) == 0 {
+		return "not verified (use --jwt-secret or --jwks)"
+	}
+
+	kid, _ := header["kid"].(string)
+	tried := 0
+	for _, key := range p.keys {
+		if kid != "" && key.kid != "" && key.kid != kid {
+			continue
+		}
+		if key.alg != "" && key.alg != alg {
+			continue
+		}
+		ok, applicable := verifyJWTSignature(alg, key.key, []byte(signingInput), signature)
+		if !applicable {
+			continue
+		}
+		tried++
+		if ok {
+			if key.kid != "" {
+				return fmt.Sprintf("valid (%s, kid %s)", alg, key.kid)
+			}
+			return fmt.Sprintf("valid (%s)", alg)
+		}
+	}
+	if tried == 0 {
+		return fmt.Sprintf("not verified (no %s key provided)", alg)
+	}
+	return fmt.Sprintf("INVALID (%s, %d key(s) tried)", alg, tried)
+}
+
+// verifyJWTSignature reports whether the signature verifies and whether the key suits the algorithm
+func verifyJWTSignature(alg string, key interface{}, signingInput, signature []byte) (ok bool, applicable bool) {
+	var hash crypto.Hash
+	switch {
+	case strings.HasSuffix(alg, "256"):
+		hash = crypto.SHA256
+	case strings.HasSuffix(alg, "384"):
+		hash = crypto.SHA384
+	case strings.HasSuffix(alg, "512"):
+		hash = crypto.SHA512
+	}
+
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		secret, isSecret := key.([]byte)
+		if !isSecret || hash == 0 {
+			return false, false
+		}
+		mac := hmac.New(hash.New, secret)
+		mac.Write(signingInput)
+		return hmac.Equal(mac.Sum(nil), signature), true
+
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		public, isRSA := key.(*rsa.PublicKey)
+		if !isRSA || hash == 0 {
+			return false, false
+		}
+		digest := jwtDigest(hash, signingInput)
+		if alg[0] == 'P' {
+			opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+			return rsa.VerifyPSS(public, hash, digest, signature, opts) == nil, true
+		}
+		return rsa.VerifyPKCS1v15(public, hash, digest, signature) == nil, true
+
+	case strings.HasPrefix(alg, "ES"):
+		public, isEC := key.(*ecdsa.PublicKey)
+		if !isEC || hash == 0 {
+			return false, false
+		}
+		// ECDSA signatures are the fixed-size concatenation r || s
+		size := (public.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return false, true
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(public, jwtDigest(hash, signingInput), r, s), true
+
+	case alg == "EdDSA" || alg == "Ed25519":
+		public, isEd := key.(ed25519.PublicKey)
+		if !isEd {
+			return false, false
+		}
+		return ed25519.Verify(public, signingInput, signature), true
+	}
+	return false, false
+}
+
+func jwtDigest(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+func (p *JWTParser) Generate() (string, error) {
+	now := time.Now().UTC()
+	jti, err := uuid.NewV7()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate jti: %v", err)
+	}
+
+	header := `{"alg":"HS256","typ":"JWT"}`
+	payload := fmt.Sprintf(`{"sub":"user_123","jti":"%s","iat":%d,"exp":%d}`, jti, now.Unix(), now.Add(time.Hour).Unix())
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(payload))
+
+	// Sign with the configured secret, or a throwaway random one
+	secret := []byte(p.settings.Secret)
+	if len(secret) == 0 {
+		secret = make([]byte, 32)
+		if _, err := rand.Read(secret); err != nil {
+			return "", fmt.Errorf("failed to generate secret: %v", err)
+		}
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signingInput))
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+func (p *JWTParser) useRegistry(r *Registry) {
+	p.registry = r
+}
+
+// nested returns the registry of the parser, or a default one for a parser used on its own
+func (p *JWTParser) nested() *Registry {
+	if p.registry == nil {
+		return NewRegistry()
+	}
+	return p.registry
+}
+
+// nestedIDs runs the registry on a value embedded in another ID and keeps the best match
+func (r *Registry) nestedIDs(field, value, forceFormat string) []types.NestedID {
+	results := r.ParseID(value, forceFormat)
+	if len(results) == 0 {
+		return nil
+	}
+	info := results[0]
+	if info.Secret {
+		value = info.Standard
+	}
+	return []types.NestedID{{Field: field, Value: value, Info: info}}
+}
+
+func decodeJWTHeader(segment string) (map[string]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+	var header map[string]interface{}
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, err
+	}
+	if _, ok := header["alg"].(string); !ok {
+		return nil, fmt.Errorf("JOSE header has no alg")
+	}
+	return header, nil
+}
+
+func decodeJWTClaims(segment string) (map[string]interface{}, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var claims map[string]interface{}
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// jwtNumericDate converts a NumericDate claim (seconds, possibly fractional) to a time
+func jwtNumericDate(value interface{}) (time.Time, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return time.Time{}, false
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, false
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)).UTC(), true
+}
+
+// jwtValueString renders a claim value: strings as-is, everything else as JSON
+func jwtValueString(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
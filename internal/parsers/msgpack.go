@@ -0,0 +1,174 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// decodeMsgpack decodes a single MessagePack value and returns it together
+// with the number of bytes consumed. Maps decode to map[string]interface{}
+// (non-string keys are formatted with %v), arrays to []interface{}, integers
+// to int64 or uint64, and bin/ext payloads to []byte.
+func decodeMsgpack(data []byte) (interface{}, int, error) {
+	if len(data) == 0 {
+		return nil, 0, fmt.Errorf("unexpected end of msgpack data")
+	}
+
+	b := data[0]
+	switch {
+	case b <= 0x7f: // positive fixint
+		return int64(b), 1, nil
+	case b >= 0xe0: // negative fixint
+		return int64(int8(b)), 1, nil
+	case b >= 0x80 && b <= 0x8f: // fixmap
+		return decodeMsgpackMap(data, 1, int(b&0x0f))
+	case b >= 0x90 && b <= 0x9f: // fixarray
+		return decodeMsgpackArray(data, 1, int(b&0x0f))
+	case b >= 0xa0 && b <= 0xbf: // fixstr
+		return decodeMsgpackBytes(data, 1, int(b&0x1f), true)
+	}
+
+	switch b {
+	case 0xc0:
+		return nil, 1, nil
+	case 0xc2:
+		return false, 1, nil
+	case 0xc3:
+		return true, 1, nil
+	case 0xc4, 0xd9: // bin8, str8
+		n, err := msgpackLength(data, 1)
+		if err != nil {
+			return nil, 0, err
+		}
+		return decodeMsgpackBytes(data, 2, n, b == 0xd9)
+	case 0xc5, 0xda: // bin16, str16
+		n, err := msgpackLength(data, 2)
+		if err != nil {
+			return nil, 0, err
+		}
+		return decodeMsgpackBytes(data, 3, n, b == 0xda)
+	case 0xc6, 0xdb: // bin32, str32
+		n, err := msgpackLength(data, 4)
+		if err != nil {
+			return nil, 0, err
+		}
+		return decodeMsgpackBytes(data, 5, n, b == 0xdb)
+	case 0xca:
+		if len(data) < 5 {
+			return nil, 0, fmt.Errorf("truncated float32")
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(data[1:5]))), 5, nil
+	case 0xcb:
+		if len(data) < 9 {
+			return nil, 0, fmt.Errorf("truncated float64")
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(data[1:9])), 9, nil
+	case 0xcc, 0xcd, 0xce, 0xcf: // uint8-64
+		size := 1 << (b - 0xcc)
+		if len(data) < 1+size {
+			return nil, 0, fmt.Errorf("truncated uint")
+		}
+		var v uint64
+		for _, c := range data[1 : 1+size] {
+			v = v<<8 | uint64(c)
+		}
+		return v, 1 + size, nil
+	case 0xd0, 0xd1, 0xd2, 0xd3: // int8-64
+		size := 1 << (b - 0xd0)
+		if len(data) < 1+size {
+			return nil, 0, fmt.Errorf("truncated int")
+		}
+		var v uint64
+		for _, c := range data[1 : 1+size] {
+			v = v<<8 | uint64(c)
+		}
+		shift := 64 - 8*size
+		return int64(v<<shift) >> shift, 1 + size, nil
+	case 0xd4, 0xd5, 0xd6, 0xd7, 0xd8: // fixext
+		size := 1 << (b - 0xd4)
+		if len(data) < 2+size {
+			return nil, 0, fmt.Errorf("truncated ext")
+		}
+		return append([]byte(nil), data[2:2+size]...), 2 + size, nil
+	case 0xdc, 0xdd: // array16, array32
+		size := 2
+		if b == 0xdd {
+			size = 4
+		}
+		n, err := msgpackLength(data, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return decodeMsgpackArray(data, 1+size, n)
+	case 0xde, 0xdf: // map16, map32
+		size := 2
+		if b == 0xdf {
+			size = 4
+		}
+		n, err := msgpackLength(data, size)
+		if err != nil {
+			return nil, 0, err
+		}
+		return decodeMsgpackMap(data, 1+size, n)
+	}
+
+	return nil, 0, fmt.Errorf("unsupported msgpack type 0x%02x", b)
+}
+
+// msgpackLength reads a big-endian length of the given size following the type byte
+func msgpackLength(data []byte, size int) (int, error) {
+	if len(data) < 1+size {
+		return 0, fmt.Errorf("truncated msgpack length")
+	}
+	n := 0
+	for _, c := range data[1 : 1+size] {
+		n = n<<8 | int(c)
+	}
+	if n < 0 || n > len(data) {
+		return 0, fmt.Errorf("msgpack length %d exceeds input", n)
+	}
+	return n, nil
+}
+
+func decodeMsgpackBytes(data []byte, offset, n int, isString bool) (interface{}, int, error) {
+	if len(data) < offset+n {
+		return nil, 0, fmt.Errorf("truncated msgpack string")
+	}
+	payload := data[offset : offset+n]
+	if isString {
+		return string(payload), offset + n, nil
+	}
+	return append([]byte(nil), payload...), offset + n, nil
+}
+
+func decodeMsgpackArray(data []byte, offset, n int) (interface{}, int, error) {
+	values := make([]interface{}, 0, n)
+	for i := 0; i < n; i++ {
+		v, used, err := decodeMsgpack(data[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		values = append(values, v)
+		offset += used
+	}
+	return values, offset, nil
+}
+
+func decodeMsgpackMap(data []byte, offset, n int) (interface{}, int, error) {
+	values := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, used, err := decodeMsgpack(data[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		offset += used
+		v, used, err := decodeMsgpack(data[offset:])
+		if err != nil {
+			return nil, 0, err
+		}
+		offset += used
+		values[fmt.Sprintf("%v", k)] = v
+	}
+	return values, offset, nil
+}
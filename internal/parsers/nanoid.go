@@ -23,7 +23,7 @@ func (p *NanoIDParser) Name() string {
 
 func (p *NanoIDParser) CanParse(input string) bool {
 	// NanoID typical length is 21, but can vary
-	if len(input) < 6 || len(input) > 255 {
+	if len(input) < 6 || len(input) > 255 || isTimeNumberForm(input) {
 		return false
 	}
 
@@ -83,10 +83,10 @@ func (p *NanoIDParser) Parse(input string) (*types.IDInfo, error) {
 	info.Extra["url_safe"] = "true"
 	info.Extra["collision_resistant"] = "true"
 
-	// Calculate collision probability approximation
-	if len(input) == 21 {
-		info.Extra["collision_probability"] = "~1% in 4 years (1 ID/hour)"
-	}
+	// Birthday bound for this length; the registry adds the same figures to every card
+	capacity := CollisionCapacity(entropy, DefaultCollisionThreshold)
+	info.Extra["collision_probability"] = fmt.Sprintf("1%% after %s IDs (%s at 1000 IDs/second)",
+		FormatIDCount(capacity), FormatSpan(capacity/1000))
 
 	return info, nil
 }
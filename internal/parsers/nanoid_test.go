@@ -138,7 +138,7 @@ func TestNanoIDParser_Parse(t *testing.T) {
 	
 	// Check collision probability for standard 21-char NanoID
 	if len(validNanoID) == 21 {
-		if info.Extra["collision_probability"] != "~1% in 4 years (1 ID/hour)" {
+		if info.Extra["collision_probability"] != "1% after 1.31e+18 IDs (4.14e+07 years at 1000 IDs/second)" {
 			t.Errorf("Expected collision probability info for 21-char NanoID, got '%s'", info.Extra["collision_probability"])
 		}
 	}
@@ -66,11 +66,24 @@ func (p *NUIDParser) Parse(input string) (*types.IDInfo, error) {
 	// Convert to bytes for binary representation
 	inputBytes := []byte(input)
 
+	// The prefix identifies the generator until it is re-randomized; the sequence is a base62
+	// counter that grows by a random 33-333 per ID
+	prefix := input[:12]
+	var sequence int64
+	for _, c := range input[12:] {
+		sequence = sequence*62 + int64(strings.IndexRune(base62Alphabet, c))
+	}
+	extra["prefix"] = prefix
+	extra["sequence"] = fmt.Sprintf("%d", sequence)
+	extra["counter_step"] = "random 33-333"
+
 	return &types.IDInfo{
 		IDType:   "NUID (NATS Unique Identifier)",
 		Standard: input,
 		Size:     132, // ~132 bits of entropy
 		Entropy:  &entropy,
+		Node1:    &prefix,
+		Sequence: &sequence,
 		Hex:      fmt.Sprintf("%x", inputBytes),
 		Binary:   inputBytes,
 		Extra:    extra,
@@ -76,6 +76,7 @@ func (p *ObjectIDParser) Parse(input string) (*types.IDInfo, error) {
 	info.Extra["machine_bytes"] = machineId
 	info.Extra["process_bytes"] = processId
 	info.Extra["counter_value"] = fmt.Sprintf("%d", counter)
+	info.Extra["counter_bits"] = "24"
 
 	return info, nil
 }
@@ -0,0 +1,245 @@
+package parsers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Base62 alphabet used by GitHub token checksums
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// idPrefix describes a platform-issued ID or credential prefix
+type idPrefix struct {
+	prefix     string
+	platform   string
+	objectType string
+	secret     bool
+}
+
+// Known prefixes, longest match wins
+var idPrefixes = []idPrefix{
+	// Stripe
+	{"cus_", "Stripe", "Customer", false},
+	{"pi_", "Stripe", "PaymentIntent", false},
+	{"pm_", "Stripe", "PaymentMethod", false},
+	{"ch_", "Stripe", "Charge", false},
+	{"py_", "Stripe", "Payment", false},
+	{"re_", "Stripe", "Refund", false},
+	{"in_", "Stripe", "Invoice", false},
+	{"ii_", "Stripe", "InvoiceItem", false},
+	{"sub_", "Stripe", "Subscription", false},
+	{"si_", "Stripe", "SubscriptionItem", false},
+	{"seti_", "Stripe", "SetupIntent", false},
+	{"price_", "Stripe", "Price", false},
+	{"prod_", "Stripe", "Product", false},
+	{"acct_", "Stripe", "Account", false},
+	{"evt_", "Stripe", "Event", false},
+	{"txn_", "Stripe", "BalanceTransaction", false},
+	{"po_", "Stripe", "Payout", false},
+	{"tr_", "Stripe", "Transfer", false},
+	{"card_", "Stripe", "Card", false},
+	{"ba_", "Stripe", "BankAccount", false},
+	{"src_", "Stripe", "Source", false},
+	{"tok_", "Stripe", "Token", false},
+	{"cs_live_", "Stripe", "Checkout Session (live)", false},
+	{"cs_test_", "Stripe", "Checkout Session (test)", false},
+	{"pk_live_", "Stripe", "Publishable key (live)", false},
+	{"pk_test_", "Stripe", "Publishable key (test)", false},
+	{"sk_live_", "Stripe", "Secret key (live)", true},
+	{"sk_test_", "Stripe", "Secret key (test)", true},
+	{"rk_live_", "Stripe", "Restricted key (live)", true},
+	{"rk_test_", "Stripe", "Restricted key (test)", true},
+	{"whsec_", "Stripe", "Webhook signing secret", true},
+
+	// GitHub tokens
+	{"ghp_", "GitHub", "Personal access token (classic)", true},
+	{"gho_", "GitHub", "OAuth access token", true},
+	{"ghu_", "GitHub", "User-to-server token", true},
+	{"ghs_", "GitHub", "Server-to-server token", true},
+	{"ghr_", "GitHub", "Refresh token", true},
+	{"github_pat_", "GitHub", "Fine-grained personal access token", true},
+
+	// Slack tokens
+	{"xoxb-", "Slack", "Bot token", true},
+	{"xoxp-", "Slack", "User token", true},
+	{"xoxa-", "Slack", "Workspace access token", true},
+	{"xoxr-", "Slack", "Refresh token", true},
+	{"xoxe-", "Slack", "Token rotation token", true},
+	{"xapp-", "Slack", "App-level token", true},
+
+	// OpenAI
+	{"sk-proj-", "OpenAI", "Project API key", true},
+	{"sk-svcacct-", "OpenAI", "Service account API key", true},
+	{"sk-", "OpenAI", "API key", true},
+	{"org-", "OpenAI", "Organization", false},
+	{"proj_", "OpenAI", "Project", false},
+	{"file-", "OpenAI", "File", false},
+	{"asst_", "OpenAI", "Assistant", false},
+	{"thread_", "OpenAI", "Thread", false},
+	{"run_", "OpenAI", "Run", false},
+	{"msg_", "OpenAI", "Message", false},
+	{"chatcmpl-", "OpenAI", "Chat completion", false},
+	{"ftjob-", "OpenAI", "Fine-tuning job", false},
+	{"batch_", "OpenAI", "Batch", false},
+	{"vs_", "OpenAI", "Vector store", false},
+
+	// Anthropic
+	{"sk-ant-api03-", "Anthropic", "API key", true},
+	{"sk-ant-admin01-", "Anthropic", "Admin API key", true},
+
+	// AWS access keys (the key ID is not secret on its own, but is sensitive)
+	{"AKIA", "AWS", "Access key ID (long-term)", false},
+	{"ASIA", "AWS", "Access key ID (temporary, STS)", false},
+}
+
+var (
+	prefixedBodyRegex = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	awsAccessKeyRegex = regexp.MustCompile(`^A[KS]IA[A-Z2-7]{16}$`)
+	githubTokenRegex  = regexp.MustCompile(`^gh[pousr]_[A-Za-z0-9]{36}$`)
+	slackIDRegex      = regexp.MustCompile(`^([UWCGDTBEFS])[A-Z0-9]{8,10}$`)
+)
+
+// Slack object types by leading letter
+var slackIDTypes = map[byte]string{
+	'U': "User",
+	'W': "User (Enterprise Grid)",
+	'C': "Channel",
+	'G': "Private channel / group",
+	'D': "Direct message",
+	'T': "Workspace (team)",
+	'B': "Bot",
+	'E': "Enterprise",
+	'F': "File",
+	'S': "User group",
+}
+
+func init() {
+	sort.SliceStable(idPrefixes, func(i, j int) bool {
+		return len(idPrefixes[i].prefix) > len(idPrefixes[j].prefix)
+	})
+}
+
+// PrefixedIDParser handles platform-issued IDs and credentials recognized by
+// their prefix (Stripe, GitHub, Slack, OpenAI, Anthropic, AWS)
+type PrefixedIDParser struct{}
+
+func (p *PrefixedIDParser) Name() string {
+	return "Prefixed"
+}
+
+func (p *PrefixedIDParser) CanParse(input string) bool {
+	if match := matchIDPrefix(input); match != nil {
+		if match.platform == "AWS" {
+			return awsAccessKeyRegex.MatchString(input)
+		}
+		body := input[len(match.prefix):]
+		return len(body) >= 8 && prefixedBodyRegex.MatchString(body)
+	}
+	return isSlackID(input)
+}
+
+func (p *PrefixedIDParser) Parse(input string) (*types.IDInfo, error) {
+	input = strings.TrimSpace(input)
+	if !p.CanParse(input) {
+		return nil, fmt.Errorf("unrecognized ID prefix")
+	}
+
+	match := matchIDPrefix(input)
+	if match == nil {
+		match = &idPrefix{prefix: input[:1], platform: "Slack", objectType: slackIDTypes[input[0]]}
+	}
+	body := input[len(match.prefix):]
+
+	extra := map[string]string{
+		"platform":    match.platform,
+		"object_type": match.objectType,
+		"prefix":      match.prefix,
+		"body_length": fmt.Sprintf("%d", len(body)),
+	}
+
+	// GitHub tokens end in a base62 CRC32 of the 30 random characters
+	if githubTokenRegex.MatchString(input) {
+		random, checksum := body[:30], body[30:]
+		extra["checksum"] = checksum
+		extra["checksum_valid"] = fmt.Sprintf("%t", githubTokenChecksum(random) == checksum)
+	}
+
+	if strings.Contains(match.objectType, "(live)") {
+		extra["mode"] = "live"
+	} else if strings.Contains(match.objectType, "(test)") {
+		extra["mode"] = "test"
+	}
+
+	entropy := int(float64(len(body)) * 5.95) // ~log2(62) per character
+	info := &types.IDInfo{
+		IDType:   fmt.Sprintf("%s %s", match.platform, match.objectType),
+		Standard: input,
+		Size:     len(input) * 8,
+		Entropy:  &entropy,
+		Hex:      hex.EncodeToString([]byte(input)),
+		Binary:   []byte(input),
+		Extra:    extra,
+	}
+
+	if match.secret {
+		info.Secret = true
+		extra["secret"] = "true (value masked)"
+		info.Standard = maskSecret(input)
+		info.Hex = ""
+		info.Binary = nil
+	}
+
+	return info, nil
+}
+
+func (p *PrefixedIDParser) Generate() (string, error) {
+	// Generate a Stripe-style customer ID
+	body := make([]byte, 14)
+	for i := range body {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(base62Alphabet))))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random character: %v", err)
+		}
+		body[i] = base62Alphabet[n.Int64()]
+	}
+	return "cus_" + string(body), nil
+}
+
+// matchIDPrefix returns the longest known prefix of input
+func matchIDPrefix(input string) *idPrefix {
+	for i := range idPrefixes {
+		if strings.HasPrefix(input, idPrefixes[i].prefix) {
+			return &idPrefixes[i]
+		}
+	}
+	return nil
+}
+
+// isSlackID reports whether input looks like a Slack object ID such as U024BE7LH
+func isSlackID(input string) bool {
+	if !slackIDRegex.MatchString(input) {
+		return false
+	}
+	// Require both digits and letters after the type letter to avoid plain words and numbers
+	body := input[1:]
+	return strings.ContainsAny(body, "0123456789") && strings.ContainsAny(body, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+}
+
+// githubTokenChecksum returns the 6-character base62 CRC32 checksum of a token's random part
+func githubTokenChecksum(random string) string {
+	n := crc32.ChecksumIEEE([]byte(random))
+	encoded := make([]byte, 6)
+	for i := 5; i >= 0; i-- {
+		encoded[i] = base62Alphabet[n%62]
+		n /= 62
+	}
+	return string(encoded)
+}
@@ -68,6 +68,7 @@ func (p *PushIDParser) Parse(input string) (*types.IDInfo, error) {
 
 	if datetime != nil {
 		extra["timestamp_part"] = input[:8]
+		extra["timestamp_precision"] = "millisecond"
 		extra["random_part"] = input[8:]
 	}
 
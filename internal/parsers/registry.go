@@ -8,34 +8,77 @@ import (
 
 // Registry manages all ID parsers
 type Registry struct {
-	parsers []types.IDParser
+	parsers       []types.IDParser
+	revealSecrets bool
 }
 
 // NewRegistry creates a new parser registry with all parsers registered
 func NewRegistry() *Registry {
-	return &Registry{
+	r := &Registry{
 		parsers: []types.IDParser{
 			&UUIDParser{},
 			&ULIDParser{},
 			&ObjectIDParser{},
 			&KSUIDParser{},
 			&XidParser{},
-			&CUIDParser{},
+			&EthereumParser{},    // 0x-prefixed, checked before generic hex parsers
+			&Bech32Parser{},      // Known SegWit HRP required
+			&Base58CheckParser{}, // Only claims inputs whose checksum verifies
+			&CIDParser{},         // Multibase prefix plus valid CID structure required
+			&AWSARNParser{},
+			&AWSResourceIDParser{}, // Known prefix plus 8 or 17 hex characters required
+			&AzureParser{},
+			&JWTParser{}, // Dot-separated base64url segments with a JOSE header
+			&IBANParser{},
+			// Check-digit parsers outrank the numeric timestamp and Snowflake parsers
+			&PaymentCardParser{}, // Luhn plus a known card network and length
+			&IMEIParser{},        // Luhn plus a known reporting body
+			&ISBNParser{},
+			&EANParser{},                      // GS1 check digit; EAN-13 also needs an assigned GS1 prefix
+			&Base32Parser{variant: "geohash"}, // Lowercase 5-12 characters mixing digits and letters, ahead of short CUID2s
+			&CUIDParser{},                     // CUID v1 (c + 24 base36, plausible time) before CUID2
 			&SCRU128Parser{},
 			&TSIDParser{},
-			&TypeIDParser{},    // Moved before NanoID to get priority
+			&ElasticsearchParser{}, // Before NanoID/PushID: only claims IDs with a plausible timestamp
+			&TypeIDParser{},        // Moved before NanoID to get priority
+			&PrefixedIDParser{},    // After TypeID so typed IDs like msg_01h... stay TypeIDs
+			&GitHubNodeIDParser{},
 			&NUIDParser{},      // NATS Unique Identifier - moved before ShortUUID
 			&ShortUUIDParser{}, // Moved before Sqids to get priority
+			&CrockfordParser{}, // Before Sqids/NanoID: only claims strings whose mod-37 check symbol validates
+			&HashidsParser{},   // Only claims hashes that re-encode to themselves; salted hashes need --hashids-salt
 			&SqidsParser{},     // Moved before NanoID to get priority
 			&NanoIDParser{},
 			&SnowflakeParserWrapper{},
+			&SonyflakeParser{}, // After Snowflake, which real Twitter/Discord IDs belong to; a second reading
 			&UnixTimeParser{},
 			&HashHexParser{},
+			&GitOIDParser{},
 			&Base58Parser{},
 			&PushIDParser{},
 			&Base32Parser{},
+			&Base32Parser{variant: "hex"},     // Only for -f base32hex; the zero value detects it
+			&Base32Parser{variant: "zbase32"}, // Only for -f zbase32; the zero value detects it
+			&Base64Parser{},                   // Needs +/-_= or a 12/16/20-byte payload
+			&GCPParser{},                      // Last: bare 12/19-digit numbers are otherwise Snowflakes or timestamps
 		},
 	}
+	for _, parser := range r.parsers {
+		r.attach(parser)
+	}
+	return r
+}
+
+// registryUser is implemented by parsers that run the registry on IDs embedded in their input
+type registryUser interface {
+	useRegistry(r *Registry)
+}
+
+// attach hands the registry to parsers that parse nested IDs, so those honour its settings
+func (r *Registry) attach(parser types.IDParser) {
+	if user, ok := parser.(registryUser); ok {
+		user.useRegistry(r)
+	}
 }
 
 // GetParser returns a parser by name
@@ -95,9 +138,79 @@ func (r *Registry) ParseID(input string, forceFormat string) []*types.IDInfo {
 		}
 	}
 
+	for _, info := range results {
+		normalizeTimes(info)
+		if info.Entropy != nil && *info.Entropy > 0 {
+			// Time-based IDs only collide within one timestamp tick, so their capacity is per tick
+			if tick, ok := TimestampTickOf(info); ok {
+				info.Collision = EstimateTickCollision(*info.Entropy, DefaultCollisionThreshold, 0, 0, 0, tick)
+			} else if info.DateTime == nil {
+				info.Collision = EstimateCollision(*info.Entropy, DefaultCollisionThreshold, 0, 0, 0)
+			}
+		}
+	}
+
+	return r.applySecretPolicy(input, results)
+}
+
+// applySecretPolicy shows secrets in full with --reveal-secrets and masks them otherwise
+func (r *Registry) applySecretPolicy(input string, results []*types.IDInfo) []*types.IDInfo {
+	if r.revealSecrets {
+		for _, info := range results {
+			if info.Secret {
+				info.Standard = input
+			}
+		}
+		return results
+	}
+	return redactSecrets(input, results)
+}
+
+// SetRevealSecrets disables masking of API keys and card numbers in parse results
+func (r *Registry) SetRevealSecrets(reveal bool) {
+	r.revealSecrets = reveal
+}
+
+// redactSecrets masks the input in every interpretation once any parser has
+// identified it as a credential, so no output mode echoes the secret back
+func redactSecrets(input string, results []*types.IDInfo) []*types.IDInfo {
+	// The first parser that flagged the input decides how it is masked
+	masked := ""
+	for _, info := range results {
+		if info.Secret {
+			masked = info.Standard
+			break
+		}
+	}
+	if masked == "" {
+		return results
+	}
+
+	for _, info := range results {
+		info.Secret = true
+		info.Standard = masked
+		info.Hex = ""
+		info.Binary = nil
+		info.Integer = nil
+		info.Base64 = nil
+		info.ShortUUID = nil
+		info.UUIDWrap = nil
+	}
 	return results
 }
 
+// ReplaceParser swaps the registered parser of the same name for a configured one
+func (r *Registry) ReplaceParser(parser types.IDParser) {
+	r.attach(parser)
+	for i, existing := range r.parsers {
+		if existing.Name() == parser.Name() {
+			r.parsers[i] = parser
+			return
+		}
+	}
+	r.parsers = append(r.parsers, parser)
+}
+
 // GetParser returns a parser by name (global function for backward compatibility)
 func GetParser(name string) types.IDParser {
 	return globalRegistry.GetParser(name)
@@ -115,25 +228,50 @@ func matchesForceFormat(parserName, forceFormat string) bool {
 
 	// Handle aliases and variations
 	aliases := map[string][]string{
-		"uuid":      {"uuid", "guid"},
-		"ulid":      {"ulid"},
-		"objectid":  {"objectid", "mongodb", "bson"},
-		"ksuid":     {"ksuid"},
-		"xid":       {"xid"},
-		"cuid":      {"cuid", "cuid2"},
-		"scru128":   {"scru128", "scru"},
-		"tsid":      {"tsid"},
-		"nuid":      {"nuid", "nats-uid", "nats-id"},
-		"nanoid":    {"nanoid", "nano-id", "nano_id"},
-		"snowflake": {"snowflake", "sf", "sf-twitter", "sf-discord", "twitter", "discord"},
-		"unixtime":  {"unixtime", "unix", "timestamp"},
-		"hashhex":   {"hashhex", "hash", "hex"},
-		"base58":    {"base58", "b58", "bitcoin"},
-		"pushid":    {"pushid", "push-id", "firebase"},
-		"base32":    {"base32", "b32"},
+		"uuid":          {"uuid", "guid"},
+		"ulid":          {"ulid"},
+		"objectid":      {"objectid", "mongodb", "bson"},
+		"ksuid":         {"ksuid"},
+		"xid":           {"xid"},
+		"cuid":          {"cuid", "cuid1", "cuid2"},
+		"scru128":       {"scru128", "scru"},
+		"tsid":          {"tsid"},
+		"nuid":          {"nuid", "nats-uid", "nats-id"},
+		"nanoid":        {"nanoid", "nano-id", "nano_id"},
+		"snowflake":     {"snowflake", "sf", "sf-twitter", "sf-discord", "twitter", "discord"},
+		"sonyflake":     {"sonyflake", "sony"},
+		"elasticsearch": {"elasticsearch", "es", "opensearch"},
+		"unixtime":      {"unixtime", "unix", "timestamp"},
+		"hashhex":       {"hashhex", "hash", "hex"},
+		"base58":        {"base58", "b58"},
+		"base58check":   {"base58check", "bitcoin", "btc", "p2pkh", "p2sh"},
+		"bech32":        {"bech32", "bech32m", "segwit"},
+		"ethereum":      {"ethereum", "eth", "eip55"},
+		"cid":           {"cid", "ipfs", "multihash"},
+		"gitoid":        {"gitoid", "git", "git-oid"},
+		"awsarn":        {"awsarn", "arn"},
+		"awsresourceid": {"awsresourceid", "aws", "aws-id"},
+		"azure":         {"azure", "arm"},
+		"gcp":           {"gcp", "gce", "google-cloud"},
+		"prefixed":      {"prefixed", "stripe", "slack", "openai", "apikey", "api-key", "token"},
+		"githubnodeid":  {"githubnodeid", "github", "node-id", "graphql"},
+		"jwt":           {"jwt", "jws", "jwe", "bearer"},
+		"iban":          {"iban"},
+		"paymentcard":   {"paymentcard", "card", "pan", "luhn", "credit-card"},
+		"imei":          {"imei", "imeisv"},
+		"isbn":          {"isbn", "isbn10", "isbn13"},
+		"ean":           {"ean", "ean13", "ean8", "upc", "upc-a", "gtin", "gs1"},
+		"pushid":        {"pushid", "push-id", "firebase"},
+		"base32":        {"base32", "b32", "rfc4648"},
+		"base32hex":     {"base32hex", "base32-hex", "b32hex"},
+		"zbase32":       {"zbase32", "z-base-32", "zbase"},
+		"geohash":       {"geohash", "gh"},
+		"base64":        {"base64", "b64", "base64url", "bindata"},
+		"crockford":     {"crockford", "crockford32", "base32-crockford"},
 
 		"shortuuid": {"shortuuid", "short-uuid", "suuid"},
 		"sqids":     {"sqids", "sqid"},
+		"hashids":   {"hashids", "hashid"},
 		"typeid":    {"typeid", "type-id"},
 	}
 
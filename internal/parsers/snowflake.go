@@ -86,8 +86,9 @@ func (p *SnowflakeParser) Parse(input string) (*types.IDInfo, error) {
 	info.Entropy = &entropy
 
 	// Add Snowflake-specific information
-	info.Extra["epoch"] = time.UnixMilli(snowflake.Epoch).Format(time.RFC3339)
+	info.Extra["epoch"] = time.UnixMilli(snowflake.Epoch).UTC().Format(time.RFC3339Nano)
 	info.Extra["timestamp_bits"] = "41"
+	info.Extra["timestamp_precision"] = "millisecond"
 	info.Extra["node_bits"] = "10"
 	info.Extra["sequence_bits"] = "12"
 	info.Extra["node_id"] = fmt.Sprintf("%d", nodeId)
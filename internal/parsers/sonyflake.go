@@ -0,0 +1,207 @@
+package parsers
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// Sonyflake bit layout: 39 bits of 10ms time units, 8 bits sequence, 16 bits machine ID
+const (
+	sonyflakeTimeBits     = 39
+	sonyflakeSequenceBits = 8
+	sonyflakeMachineBits  = 16
+	sonyflakeTimeUnit     = 10 * time.Millisecond
+)
+
+// sonyflakeDefaultEpoch is the start time used by github.com/sony/sonyflake
+var sonyflakeDefaultEpoch = time.Date(2014, 9, 1, 0, 0, 0, 0, time.UTC)
+
+var sonyflakeRegex = regexp.MustCompile(`^\d{15,19}$`)
+
+// SonyflakeSettings configures the epoch and machine ID of a SonyflakeParser
+type SonyflakeSettings struct {
+	StartTime time.Time // Zero means 2014-09-01T00:00:00Z
+	MachineID *uint16   // Nil means lower 16 bits of a private IPv4 address
+}
+
+// SonyflakeParser handles parsing and generation of Sonyflake IDs
+type SonyflakeParser struct {
+	settings SonyflakeSettings
+
+	mu          sync.Mutex
+	elapsedTime int64
+	sequence    uint16
+}
+
+// NewSonyflakeParser creates a Sonyflake parser with custom settings
+func NewSonyflakeParser(settings SonyflakeSettings) *SonyflakeParser {
+	return &SonyflakeParser{settings: settings}
+}
+
+func (p *SonyflakeParser) Name() string {
+	return "Sonyflake"
+}
+
+func (p *SonyflakeParser) startTime() time.Time {
+	if p.settings.StartTime.IsZero() {
+		return sonyflakeDefaultEpoch
+	}
+	return p.settings.StartTime
+}
+
+func (p *SonyflakeParser) CanParse(input string) bool {
+	if !sonyflakeRegex.MatchString(input) {
+		return false
+	}
+
+	id, err := strconv.ParseUint(input, 10, 63)
+	if err != nil {
+		return false
+	}
+
+	// Sonyflake shares the numeric space with Snowflake and Unix timestamps,
+	// so only claim IDs whose decoded time falls between a few months after
+	// the epoch and a day from now
+	t := p.decodeTime(id)
+	start := p.startTime()
+	if t.Before(start.AddDate(0, 4, 0)) || t.After(time.Now().Add(24*time.Hour)) {
+		return false
+	}
+	// A configured machine ID narrows detection to that machine's IDs
+	return p.settings.MachineID == nil || uint16(id&(1<<sonyflakeMachineBits-1)) == *p.settings.MachineID
+}
+
+func (p *SonyflakeParser) Parse(input string) (*types.IDInfo, error) {
+	id, err := strconv.ParseUint(input, 10, 63)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Sonyflake format: %v", err)
+	}
+
+	elapsed := id >> (sonyflakeSequenceBits + sonyflakeMachineBits)
+	sequence := int64((id >> sonyflakeMachineBits) & (1<<sonyflakeSequenceBits - 1))
+	machineID := uint16(id & (1<<sonyflakeMachineBits - 1))
+
+	timestamp := p.decodeTime(id)
+	timestampStr := fmt.Sprintf("%.3f", float64(timestamp.UnixMilli())/1000)
+
+	info := &types.IDInfo{
+		IDType:    "Sonyflake",
+		Standard:  input,
+		Size:      64,
+		Hex:       fmt.Sprintf("%016x", id),
+		Binary:    new(big.Int).SetUint64(id).FillBytes(make([]byte, 8)),
+		DateTime:  &timestamp,
+		Timestamp: &timestampStr,
+		Sequence:  &sequence,
+		Extra:     make(map[string]string),
+	}
+
+	info.Integer = &input
+
+	machineStr := fmt.Sprintf("%d", machineID)
+	info.Node1 = &machineStr
+	machineIP := fmt.Sprintf("*.*.%d.%d", machineID>>8, machineID&0xff)
+	info.Node2 = &machineIP
+
+	// Sonyflake IDs carry no randomness: time, sequence and machine are all deterministic
+	entropy := 0
+	info.Entropy = &entropy
+
+	info.Extra["epoch"] = p.startTime().UTC().Format(time.RFC3339)
+	info.Extra["time_unit"] = "10ms"
+	info.Extra["timestamp_precision"] = "10 milliseconds"
+	info.Extra["elapsed_time_units"] = fmt.Sprintf("%d", elapsed)
+	info.Extra["timestamp_bits"] = fmt.Sprintf("%d", sonyflakeTimeBits)
+	info.Extra["sequence_bits"] = fmt.Sprintf("%d", sonyflakeSequenceBits)
+	info.Extra["machine_bits"] = fmt.Sprintf("%d", sonyflakeMachineBits)
+	info.Extra["machine_id"] = machineStr
+	info.Extra["machine_ip"] = machineIP
+	info.Extra["sequence_number"] = fmt.Sprintf("%d", sequence)
+	info.Extra["lifetime_end"] = p.startTime().Add(time.Duration(1<<sonyflakeTimeBits) * sonyflakeTimeUnit).UTC().Format(time.RFC3339)
+
+	return info, nil
+}
+
+func (p *SonyflakeParser) Generate() (string, error) {
+	machineID, err := p.machineID()
+	if err != nil {
+		return "", err
+	}
+
+	start := p.startTime()
+	if start.After(time.Now()) {
+		return "", fmt.Errorf("sonyflake start time %s is in the future", start.Format(time.RFC3339))
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	current := int64(time.Since(start) / sonyflakeTimeUnit)
+	if p.elapsedTime < current {
+		p.elapsedTime = current
+		p.sequence = 0
+	} else {
+		// Same time unit (or clock moved back): bump the sequence, borrowing
+		// the next time unit when it overflows like the reference implementation
+		p.sequence = (p.sequence + 1) & (1<<sonyflakeSequenceBits - 1)
+		if p.sequence == 0 {
+			p.elapsedTime++
+			time.Sleep(time.Until(start.Add(time.Duration(p.elapsedTime) * sonyflakeTimeUnit)))
+		}
+	}
+
+	if p.elapsedTime >= 1<<sonyflakeTimeBits {
+		return "", fmt.Errorf("sonyflake time exceeds the 39-bit limit")
+	}
+
+	id := uint64(p.elapsedTime)<<(sonyflakeSequenceBits+sonyflakeMachineBits) |
+		uint64(p.sequence)<<sonyflakeMachineBits |
+		uint64(machineID)
+	return strconv.FormatUint(id, 10), nil
+}
+
+// decodeTime converts the time component of a Sonyflake ID to a wall-clock time
+func (p *SonyflakeParser) decodeTime(id uint64) time.Time {
+	elapsed := int64(id >> (sonyflakeSequenceBits + sonyflakeMachineBits))
+	return p.startTime().Add(time.Duration(elapsed) * sonyflakeTimeUnit).UTC()
+}
+
+// machineID returns the configured machine ID or derives one from a private IPv4 address
+func (p *SonyflakeParser) machineID() (uint16, error) {
+	if p.settings.MachineID != nil {
+		return *p.settings.MachineID, nil
+	}
+
+	ip, err := privateIPv4()
+	if err != nil {
+		return 0, fmt.Errorf("failed to derive sonyflake machine ID: %v", err)
+	}
+	return uint16(ip[2])<<8 + uint16(ip[3]), nil
+}
+
+// privateIPv4 returns the first RFC 1918 or link-local IPv4 address of this host
+func privateIPv4() (net.IP, error) {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		ipnet, ok := addr.(*net.IPNet)
+		if !ok || ipnet.IP.IsLoopback() {
+			continue
+		}
+		ip := ipnet.IP.To4()
+		if ip != nil && (ip.IsPrivate() || ip.IsLinkLocalUnicast()) {
+			return ip, nil
+		}
+	}
+	return nil, fmt.Errorf("no private IPv4 address found")
+}
@@ -0,0 +1,156 @@
+package parsers
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSonyflakeParser_Name(t *testing.T) {
+	parser := &SonyflakeParser{}
+	if parser.Name() != "Sonyflake" {
+		t.Errorf("Expected name 'Sonyflake', got '%s'", parser.Name())
+	}
+}
+
+func TestSonyflakeParser_CanParse(t *testing.T) {
+	parser := &SonyflakeParser{}
+
+	// 2023-11-14T22:13:20Z, sequence 3, machine 0x0a0b
+	elapsed := uint64(time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC).Sub(sonyflakeDefaultEpoch) / sonyflakeTimeUnit)
+	valid := strconv.FormatUint(elapsed<<24|3<<16|0x0a0b, 10)
+
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{valid, true},
+		{"", false},
+		{"1700000000", false},          // Unix seconds
+		{"1700000000000", false},       // Unix milliseconds
+		{"1777150623882019211", false}, // Twitter Snowflake decodes to the far future
+		{"abc123456789012345", false},
+	}
+
+	for _, test := range tests {
+		if result := parser.CanParse(test.input); result != test.expected {
+			t.Errorf("CanParse(%q) = %v, expected %v", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestSonyflakeParser_AutoDetect(t *testing.T) {
+	// A 2013 Twitter ID also decodes to a plausible Sonyflake time; Snowflake must come first
+	registry := NewRegistry()
+	var idTypes []string
+	for _, info := range registry.ParseID("381870553235193857", "") {
+		idTypes = append(idTypes, info.IDType)
+	}
+	snowflake, sonyflake := -1, -1
+	for i, idType := range idTypes {
+		if idType == "Snowflake" && snowflake < 0 {
+			snowflake = i
+		}
+		if idType == "Sonyflake" && sonyflake < 0 {
+			sonyflake = i
+		}
+	}
+	if snowflake < 0 || sonyflake >= 0 && sonyflake < snowflake {
+		t.Errorf("Expected Snowflake ahead of Sonyflake, got %v", idTypes)
+	}
+
+	// A configured machine ID only detects that machine's IDs
+	elapsed := uint64(time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC).Sub(sonyflakeDefaultEpoch) / sonyflakeTimeUnit)
+	id := strconv.FormatUint(elapsed<<24|3<<16|0x0a0b, 10)
+	for machine, want := range map[uint16]bool{0x0a0b: true, 42: false} {
+		registry.ReplaceParser(NewSonyflakeParser(SonyflakeSettings{MachineID: &machine}))
+		found := false
+		for _, info := range registry.ParseID(id, "") {
+			found = found || info.IDType == "Sonyflake"
+		}
+		if found != want {
+			t.Errorf("Machine %d: expected Sonyflake detected = %v", machine, want)
+		}
+	}
+}
+
+func TestSonyflakeParser_Parse(t *testing.T) {
+	parser := &SonyflakeParser{}
+
+	expected := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+	elapsed := uint64(expected.Sub(sonyflakeDefaultEpoch) / sonyflakeTimeUnit)
+	input := strconv.FormatUint(elapsed<<24|3<<16|0x0a0b, 10)
+
+	info, err := parser.Parse(input)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if info.IDType != "Sonyflake" {
+		t.Errorf("Expected IDType 'Sonyflake', got %q", info.IDType)
+	}
+	if info.DateTime == nil || !info.DateTime.Equal(expected) {
+		t.Errorf("Expected DateTime %v, got %v", expected, info.DateTime)
+	}
+	if info.Sequence == nil || *info.Sequence != 3 {
+		t.Errorf("Expected sequence 3, got %v", info.Sequence)
+	}
+	if info.Node1 == nil || *info.Node1 != "2571" {
+		t.Errorf("Expected machine ID 2571, got %v", info.Node1)
+	}
+	if info.Extra["machine_ip"] != "*.*.10.11" {
+		t.Errorf("Expected machine_ip '*.*.10.11', got %q", info.Extra["machine_ip"])
+	}
+	if len(info.Binary) != 8 {
+		t.Errorf("Expected 8 binary bytes, got %d", len(info.Binary))
+	}
+
+	if _, err := parser.Parse("not-a-number"); err == nil {
+		t.Error("Expected error for invalid Sonyflake")
+	}
+}
+
+func TestSonyflakeParser_Generate(t *testing.T) {
+	machineID := uint16(0x0102)
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	parser := NewSonyflakeParser(SonyflakeSettings{StartTime: start, MachineID: &machineID})
+
+	seen := make(map[string]bool)
+	var previous uint64
+	for i := 0; i < 600; i++ {
+		id, err := parser.Generate()
+		if err != nil {
+			t.Fatalf("Generate failed: %v", err)
+		}
+		if seen[id] {
+			t.Fatalf("Generated duplicate Sonyflake: %s", id)
+		}
+		seen[id] = true
+
+		value, _ := strconv.ParseUint(id, 10, 64)
+		if value <= previous {
+			t.Fatalf("Generated Sonyflakes are not increasing: %d after %d", value, previous)
+		}
+		previous = value
+	}
+
+	generated, _ := parser.Generate()
+	info, err := parser.Parse(generated)
+	if err != nil {
+		t.Fatalf("Failed to parse generated Sonyflake: %v", err)
+	}
+	if info.Node1 == nil || *info.Node1 != "258" {
+		t.Errorf("Expected machine ID 258, got %v", info.Node1)
+	}
+	if info.Extra["epoch"] != "2020-01-01T00:00:00Z" {
+		t.Errorf("Expected custom epoch, got %q", info.Extra["epoch"])
+	}
+	if diff := time.Since(*info.DateTime); diff < -time.Second || diff > time.Minute {
+		t.Errorf("Generated Sonyflake timestamp should be recent, got %v", info.DateTime)
+	}
+
+	future := NewSonyflakeParser(SonyflakeSettings{StartTime: time.Now().Add(time.Hour), MachineID: &machineID})
+	if _, err := future.Generate(); err == nil {
+		t.Error("Expected error for start time in the future")
+	}
+}
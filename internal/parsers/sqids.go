@@ -17,7 +17,7 @@ func (p *SqidsParser) Name() string {
 
 func (p *SqidsParser) CanParse(input string) bool {
 	// Empty strings are invalid
-	if len(input) == 0 {
+	if len(input) == 0 || isTimeNumberForm(input) {
 		return false
 	}
 
@@ -0,0 +1,160 @@
+package parsers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// histogramBuckets lists the bucket sizes a histogram can use
+var histogramBuckets = []string{"minute", "hour", "day"}
+
+// histogramMaxBuckets limits how many buckets one outlying timestamp can make a histogram draw
+const histogramMaxBuckets = 10000
+
+// Timeline decodes the embedded timestamp of IDs fed to it one line at a time and keeps those
+// inside the [Since, Until) window. Like the other corpus commands, every line is parsed as the
+// format of the first one that parses unless a format is forced.
+type Timeline struct {
+	registry    *Registry
+	forceFormat string
+	parser      types.IDParser
+	since       *time.Time
+	until       *time.Time
+	line        int
+
+	// Skipped counts lines that did not parse or carry no timestamp, Filtered those outside the window
+	Skipped  int
+	Filtered int
+}
+
+// NewTimeline creates a timeline; since and until are optional bounds
+func (r *Registry) NewTimeline(forceFormat string, since, until *time.Time) *Timeline {
+	return &Timeline{registry: r, forceFormat: forceFormat, since: since, until: until}
+}
+
+// Add decodes one input line and returns its timestamp, or nil when the line is blank, cannot be
+// parsed, has no timestamp or falls outside the window
+func (t *Timeline) Add(input string) *types.TimedID {
+	t.line++
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return nil
+	}
+	if t.parser == nil {
+		t.parser = t.registry.corpusParser(input, t.forceFormat)
+		if t.parser == nil {
+			t.Skipped++
+			return nil
+		}
+	}
+	info, err := t.parser.Parse(input)
+	if err != nil || info.DateTime == nil {
+		t.Skipped++
+		return nil
+	}
+	normalizeTimes(info)
+	if t.since != nil && info.DateTime.Before(*t.since) || t.until != nil && !info.DateTime.Before(*t.until) {
+		t.Filtered++
+		return nil
+	}
+
+	id := input
+	if info.Secret && !t.registry.revealSecrets {
+		id = info.Standard
+	}
+	return &types.TimedID{Line: t.line, ID: id, Format: info.IDType, DateTime: *info.DateTime}
+}
+
+// SortByTime orders IDs by their timestamp, keeping input order for equal timestamps
+func SortByTime(ids []*types.TimedID, reverse bool) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		if reverse {
+			return ids[i].DateTime.After(ids[j].DateTime)
+		}
+		return ids[i].DateTime.Before(ids[j].DateTime)
+	})
+}
+
+// Histogram counts timestamps per minute, hour or day bucket without keeping the IDs. Buckets
+// follow the wall clock of its location, so day buckets start at local midnight.
+type Histogram struct {
+	bucket   string
+	location *time.Location
+	counts   map[int64]int
+	total    int
+}
+
+// NewHistogram creates a histogram with minute, hour or day buckets in loc
+func NewHistogram(bucket string, loc *time.Location) (*Histogram, error) {
+	bucket = strings.ToLower(strings.TrimSpace(bucket))
+	if !slices.Contains(histogramBuckets, bucket) {
+		return nil, fmt.Errorf("unknown bucket '%s' (use minute, hour or day)", bucket)
+	}
+	if loc == nil {
+		loc = time.UTC
+	}
+	return &Histogram{bucket: bucket, location: loc, counts: map[int64]int{}}, nil
+}
+
+// Add counts one timestamp
+func (h *Histogram) Add(t time.Time) {
+	h.counts[h.bucketStart(t).Unix()]++
+	h.total++
+}
+
+// bucketStart returns the start of the bucket holding t
+func (h *Histogram) bucketStart(t time.Time) time.Time {
+	t = t.In(h.location)
+	y, mo, d := t.Date()
+	switch h.bucket {
+	case "minute":
+		return time.Date(y, mo, d, t.Hour(), t.Minute(), 0, 0, h.location)
+	case "hour":
+		return time.Date(y, mo, d, t.Hour(), 0, 0, 0, h.location)
+	}
+	return time.Date(y, mo, d, 0, 0, 0, 0, h.location)
+}
+
+// nextBucket returns the start of the bucket after the one starting at start
+func (h *Histogram) nextBucket(start time.Time) time.Time {
+	switch h.bucket {
+	case "minute":
+		return start.Add(time.Minute)
+	case "hour":
+		return start.Add(time.Hour)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Result lists every bucket from the first to the last timestamp, including empty ones so gaps
+// in the timeline show up
+func (h *Histogram) Result() (*types.Histogram, error) {
+	result := &types.Histogram{Bucket: h.bucket, Count: h.total}
+	if h.total == 0 {
+		return result, nil
+	}
+	starts := make([]int64, 0, len(h.counts))
+	for start := range h.counts {
+		starts = append(starts, start)
+	}
+	last := slices.Max(starts)
+	for start := time.Unix(slices.Min(starts), 0).In(h.location); start.Unix() <= last; {
+		if len(result.Buckets) == histogramMaxBuckets {
+			return nil, fmt.Errorf("timestamps span more than %d %s buckets; use a larger bucket or narrow --since/--until", histogramMaxBuckets, h.bucket)
+		}
+		result.Buckets = append(result.Buckets, types.HistogramBucket{Start: start, Count: h.counts[start.Unix()]})
+
+		// The wall-clock hour a DST change repeats maps to the bucket already listed
+		next := h.nextBucket(start)
+		for !h.bucketStart(next).After(start) {
+			next = h.nextBucket(next)
+		}
+		start = h.bucketStart(next)
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package parsers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+func TestTimeline_Window(t *testing.T) {
+	since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2020, 7, 26, 14, 0, 0, 0, time.UTC)
+	timeline := NewRegistry().NewTimeline("", &since, &until)
+
+	var kept []*types.TimedID
+	for _, input := range []string{
+		"5f1d7f3e0000000000000001", // 2020-07-26 13:03:58
+		"507f1f77bcf86cd799439011", // 2012, before the window
+		"",
+		"not-an-objectid",
+		"5f1d8f3e0000000000000001", // 14:12:14, at or after --until
+		"5f1d7f000000000000000002", // 13:02:56
+	} {
+		if id := timeline.Add(input); id != nil {
+			kept = append(kept, id)
+		}
+	}
+	if len(kept) != 2 {
+		t.Fatalf("Expected 2 IDs inside the window, got %d", len(kept))
+	}
+	if kept[1].Line != 6 {
+		t.Errorf("Expected line 6, got %d", kept[1].Line)
+	}
+	if timeline.Filtered != 2 || timeline.Skipped != 1 {
+		t.Errorf("Expected 2 filtered and 1 skipped, got %d and %d", timeline.Filtered, timeline.Skipped)
+	}
+
+	SortByTime(kept, false)
+	if kept[0].ID != "5f1d7f000000000000000002" {
+		t.Errorf("Expected the 13:02:56 ID first, got %s", kept[0].ID)
+	}
+	SortByTime(kept, true)
+	if kept[0].ID != "5f1d7f3e0000000000000001" {
+		t.Errorf("Expected the 13:03:58 ID first in reverse, got %s", kept[0].ID)
+	}
+}
+
+func TestHistogram_Buckets(t *testing.T) {
+	h, err := NewHistogram("minute", time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	for _, offset := range []time.Duration{5 * time.Second, 30 * time.Second, 3*time.Minute + 59*time.Second} {
+		h.Add(base.Add(offset))
+	}
+	result, err := h.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 10:00 through 10:03, with the two quiet minutes in between kept as empty buckets
+	expected := []int{2, 0, 0, 1}
+	if len(result.Buckets) != len(expected) {
+		t.Fatalf("Expected %d buckets, got %d", len(expected), len(result.Buckets))
+	}
+	for i, count := range expected {
+		if result.Buckets[i].Count != count {
+			t.Errorf("Bucket %d: expected %d, got %d", i, count, result.Buckets[i].Count)
+		}
+	}
+	if !result.Buckets[0].Start.Equal(base) {
+		t.Errorf("Expected the first bucket to start at %s, got %s", base, result.Buckets[0].Start)
+	}
+
+	h.Add(base.AddDate(-1, 0, 0))
+	if _, err := h.Result(); err == nil {
+		t.Error("Expected an error for a year of minute buckets")
+	}
+	if _, err := NewHistogram("fortnight", time.UTC); err == nil {
+		t.Error("Expected an error for an unknown bucket")
+	}
+}
+
+func TestHistogram_DaylightSaving(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	h, _ := NewHistogram("hour", loc)
+	// Clocks went back at 02:00 EDT on 2024-11-03, so 01:00-02:00 local happened twice
+	first := time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC)  // 01:30 EDT
+	second := time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC) // 01:30 EST
+	third := time.Date(2024, 11, 3, 7, 30, 0, 0, time.UTC)  // 02:30 EST
+	for _, ts := range []time.Time{first, second, third} {
+		h.Add(ts)
+	}
+	result, err := h.Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Buckets) != 2 || result.Buckets[0].Count != 2 || result.Buckets[1].Count != 1 {
+		t.Errorf("Expected the repeated hour in one bucket, got %+v", result.Buckets)
+	}
+
+	h, _ = NewHistogram("day", loc)
+	h.Add(time.Date(2024, 11, 3, 3, 0, 0, 0, time.UTC)) // 23:00 on the 2nd in New York
+	h.Add(first)
+	result, _ = h.Result()
+	if len(result.Buckets) != 2 || result.Buckets[1].Start.Format("2006-01-02 15:04") != "2024-11-03 00:00" {
+		t.Errorf("Expected day buckets starting at local midnight, got %+v", result.Buckets)
+	}
+}
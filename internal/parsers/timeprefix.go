@@ -0,0 +1,177 @@
+package parsers
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/rs/xid"
+	tsid "github.com/rushysloth/go-tsid"
+	"github.com/segmentio/ksuid"
+
+	"github.com/zcyc/idinfo/internal/types"
+)
+
+// timePrefixFormat describes how a time-sortable format encodes its timestamp
+type timePrefixFormat struct {
+	name string
+	// tick is the timestamp's resolution; first and last are the earliest and latest ticks it can hold
+	tick        time.Duration
+	first, last time.Time
+	// encode returns the smallest (high false) or largest (high true) ID created at a tick
+	encode func(t time.Time, high bool) string
+}
+
+// fill returns n bytes that are all zero, or all one bits when high is set
+func fill(n int, high bool) []byte {
+	b := make([]byte, n)
+	if high {
+		for i := range b {
+			b[i] = 0xff
+		}
+	}
+	return b
+}
+
+var timePrefixFormats = []timePrefixFormat{
+	{
+		name: "ULID", tick: time.Millisecond,
+		first: time.UnixMilli(0), last: time.UnixMilli(int64(ulid.MaxTime())),
+		encode: func(t time.Time, high bool) string {
+			var id ulid.ULID
+			copy(id[6:], fill(10, high))
+			id.SetTime(uint64(t.UnixMilli()))
+			return id.String()
+		},
+	},
+	{
+		name: "MongoDB ObjectId", tick: time.Second,
+		first: time.Unix(0, 0), last: time.Unix(1<<32-1, 0),
+		encode: func(t time.Time, high bool) string {
+			b := fill(12, high)
+			binary.BigEndian.PutUint32(b, uint32(t.Unix()))
+			return hex.EncodeToString(b)
+		},
+	},
+	{
+		name: "UUIDv7", tick: time.Millisecond,
+		first: time.UnixMilli(0), last: time.UnixMilli(1<<48 - 1),
+		encode: func(t time.Time, high bool) string {
+			var id uuid.UUID
+			copy(id[:], fill(16, high))
+			ms := uint64(t.UnixMilli())
+			for i := 0; i < 6; i++ {
+				id[i] = byte(ms >> (40 - 8*i))
+			}
+			id[6] = id[6]&0x0f | 0x70
+			id[8] = id[8]&0x3f | 0x80
+			return id.String()
+		},
+	},
+	{
+		name: "KSUID", tick: time.Second,
+		first: time.Unix(ksuidEpoch, 0), last: time.Unix(ksuidEpoch+1<<32-1, 0),
+		encode: func(t time.Time, high bool) string {
+			b := fill(20, high)
+			binary.BigEndian.PutUint32(b, uint32(t.Unix()-ksuidEpoch))
+			id, _ := ksuid.FromBytes(b)
+			return id.String()
+		},
+	},
+	{
+		name: "Xid", tick: time.Second,
+		first: time.Unix(0, 0), last: time.Unix(1<<32-1, 0),
+		encode: func(t time.Time, high bool) string {
+			b := fill(12, high)
+			binary.BigEndian.PutUint32(b, uint32(t.Unix()))
+			id, _ := xid.FromBytes(b)
+			return id.String()
+		},
+	},
+	{
+		name: "TSID", tick: time.Millisecond,
+		first: time.UnixMilli(tsid.TSID_EPOCH), last: time.UnixMilli(tsid.TSID_EPOCH + 1<<42 - 1),
+		encode: func(t time.Time, high bool) string {
+			number := uint64(t.UnixMilli()-tsid.TSID_EPOCH) << tsid.RANDOM_BITS
+			if high {
+				number |= 1<<tsid.RANDOM_BITS - 1
+			}
+			return tsid.FromNumber(int64(number)).ToString()
+		},
+	},
+}
+
+// ksuidEpoch is the Unix time of KSUID timestamp zero
+const ksuidEpoch = 1400000000
+
+// TimePrefixes shows, for every time-sortable format, the first and last ID that could be
+// created at t and the longest prefix they share, for LIKE 'prefix%' queries and log greps.
+// Where the timestamp does not end on a character boundary, the prefix also matches IDs from
+// neighbouring ticks; the span it matches is reported with it.
+func TimePrefixes(t time.Time) *types.TimePrefixReport {
+	report := &types.TimePrefixReport{At: t.UTC()}
+	for _, format := range timePrefixFormats {
+		entry := types.TimePrefix{Format: format.name, Precision: format.tick.String()}
+		tick := t.Truncate(format.tick)
+		if tick.Before(format.first) || tick.After(format.last) {
+			entry.Error = fmt.Sprintf("outside the range %s can encode (%s to %s)", format.name,
+				format.first.UTC().Format(time.RFC3339), format.last.UTC().Format(time.RFC3339))
+			report.Prefixes = append(report.Prefixes, entry)
+			continue
+		}
+
+		entry.First = format.encode(tick, false)
+		entry.Last = format.encode(tick, true)
+		entry.Prefix = commonPrefix(entry.First, entry.Last)
+
+		// IDs sort by time, so the ticks matching the prefix are contiguous around t
+		from := tick.Add(-time.Duration(prefixTicks(format, tick, -1, entry.Prefix)) * format.tick)
+		until := tick.Add(time.Duration(prefixTicks(format, tick, 1, entry.Prefix)+1) * format.tick)
+		from, until = from.UTC(), until.UTC()
+		entry.MatchesFrom, entry.MatchesUntil = &from, &until
+		report.Prefixes = append(report.Prefixes, entry)
+	}
+	return report
+}
+
+// prefixTicks counts how many ticks beyond tick in direction dir (-1 or 1) still have IDs
+// starting with prefix, by doubling and then bisecting the step
+func prefixTicks(format timePrefixFormat, tick time.Time, dir int, prefix string) int64 {
+	matches := func(n int64) bool {
+		t := tick.Add(time.Duration(int64(dir)*n) * format.tick)
+		if t.Before(format.first) || t.After(format.last) {
+			return false
+		}
+		// Earlier ticks match if their largest ID does, later ones if their smallest does
+		return strings.HasPrefix(format.encode(t, dir < 0), prefix)
+	}
+	low, high := int64(0), int64(1)
+	for matches(high) {
+		low, high = high, high*2
+		if high > 1<<48 {
+			return low
+		}
+	}
+	for high-low > 1 {
+		mid := low + (high-low)/2
+		if matches(mid) {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low
+}
+
+// commonPrefix returns the longest prefix two strings share
+func commonPrefix(a, b string) string {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return a[:n]
+}
@@ -0,0 +1,78 @@
+package parsers
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTimePrefixes(t *testing.T) {
+	at := time.Date(2024, 3, 1, 10, 0, 0, 123456789, time.UTC)
+	registry := NewRegistry()
+
+	expected := map[string]struct {
+		prefix      string
+		from, until time.Time
+	}{
+		"ULID":             {"01HQWQ9NBV", at.Truncate(time.Millisecond), at.Truncate(time.Millisecond).Add(time.Millisecond)},
+		"MongoDB ObjectId": {"65e1a720", at.Truncate(time.Second), at.Truncate(time.Second).Add(time.Second)},
+		"UUIDv7":           {"018df974-d57b-7", at.Truncate(time.Millisecond), at.Truncate(time.Millisecond).Add(time.Millisecond)},
+		// 32 timestamp bits end two bits into the seventh base32 character, so 4 seconds share 6 characters
+		"Xid": {"cngqe8", time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC), time.Date(2024, 3, 1, 10, 0, 4, 0, time.UTC)},
+		// 42 bits end three bits into the ninth character, so 8 ms share 8 characters
+		"TSID": {"048XN0DF", time.Date(2024, 3, 1, 10, 0, 0, 120e6, time.UTC), time.Date(2024, 3, 1, 10, 0, 0, 128e6, time.UTC)},
+	}
+
+	report := TimePrefixes(at)
+	if len(report.Prefixes) != len(timePrefixFormats) {
+		t.Fatalf("Expected %d formats, got %d", len(timePrefixFormats), len(report.Prefixes))
+	}
+	for _, p := range report.Prefixes {
+		if p.Error != "" {
+			t.Errorf("%s: unexpected error %s", p.Format, p.Error)
+			continue
+		}
+		if !strings.HasPrefix(p.First, p.Prefix) || !strings.HasPrefix(p.Last, p.Prefix) || p.First >= p.Last {
+			t.Errorf("%s: %s and %s do not bracket prefix %s", p.Format, p.First, p.Last, p.Prefix)
+		}
+		// Both ends decode back to the instant
+		for _, id := range []string{p.First, p.Last} {
+			results := registry.ParseID(id, "")
+			if len(results) == 0 || results[0].DateTime == nil {
+				t.Errorf("%s: %s does not parse", p.Format, id)
+				continue
+			}
+			if got := *results[0].DateTime; !got.Equal(at.Truncate(time.Second)) && !got.Equal(at.Truncate(time.Millisecond)) {
+				t.Errorf("%s: %s decodes to %s", p.Format, id, got)
+			}
+		}
+		if p.MatchesFrom.After(at) || !p.MatchesUntil.After(at) {
+			t.Errorf("%s: span %s to %s does not contain the instant", p.Format, p.MatchesFrom, p.MatchesUntil)
+		}
+
+		want, ok := expected[p.Format]
+		if !ok {
+			continue
+		}
+		if p.Prefix != want.prefix {
+			t.Errorf("%s: expected prefix %s, got %s", p.Format, want.prefix, p.Prefix)
+		}
+		if !p.MatchesFrom.Equal(want.from) || !p.MatchesUntil.Equal(want.until) {
+			t.Errorf("%s: expected span %s to %s, got %s to %s", p.Format, want.from, want.until, p.MatchesFrom, p.MatchesUntil)
+		}
+	}
+}
+
+func TestTimePrefixes_OutOfRange(t *testing.T) {
+	// Before the KSUID (2014) and TSID (2023) epochs
+	report := TimePrefixes(time.Date(2010, 1, 1, 0, 0, 0, 0, time.UTC))
+	for _, p := range report.Prefixes {
+		outside := p.Format == "KSUID" || p.Format == "TSID"
+		if outside != (p.Error != "") {
+			t.Errorf("%s: unexpected error state %q", p.Format, p.Error)
+		}
+		if outside && p.Prefix != "" {
+			t.Errorf("%s: expected no prefix outside its range, got %s", p.Format, p.Prefix)
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package parsers
 
 import (
+	"encoding/binary"
 	"fmt"
+	"math/big"
 	"regexp"
 	"strconv"
 	"strings"
@@ -30,7 +32,13 @@ func (p *TSIDParser) CanParse(input string) bool {
 	}
 
 	// Check against Crockford Base32 alphabet (case-insensitive)
-	return tsidRegex.MatchString(strings.ToUpper(input))
+	if !tsidRegex.MatchString(strings.ToUpper(input)) {
+		return false
+	}
+
+	// 13 characters carry 65 bits, so the first one must leave the top bit clear
+	_, err := crockfordDecodeBytes(input, 8)
+	return err == nil
 }
 
 func (p *TSIDParser) Parse(input string) (*types.IDInfo, error) {
@@ -40,28 +48,19 @@ func (p *TSIDParser) Parse(input string) (*types.IDInfo, error) {
 		return nil, fmt.Errorf("invalid TSID format")
 	}
 
-	// Parse TSID using official library
-	parsedTsid := tsid.FromString(input)
-	if parsedTsid == nil {
-		return nil, fmt.Errorf("failed to parse TSID: invalid format")
+	raw, err := crockfordDecodeBytes(input, 8)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse TSID: %v", err)
 	}
+	number := binary.BigEndian.Uint64(raw)
 
-	// Get the number representation
-	number := parsedTsid.ToNumber()
-
-	// Extract timestamp (Unix milliseconds)
-	unixMillis := parsedTsid.GetUnixMillis()
+	// Extract timestamp (milliseconds since the TSID epoch)
+	unixMillis := int64(number>>tsid.RANDOM_BITS) + tsid.TSID_EPOCH
 	timestamp := time.UnixMilli(unixMillis)
 	timestampStr := fmt.Sprintf("%.3f", float64(unixMillis)/1000)
 
-	// Convert to binary representation
-	binary := make([]byte, 8)
-	for i := 0; i < 8; i++ {
-		binary[7-i] = byte(number >> (i * 8))
-	}
-
 	// Integer representation as string
-	intStr := strconv.FormatInt(number, 10)
+	intStr := strconv.FormatUint(number, 10)
 
 	// Extract components based on TSID specification
 	// Time component: 42 bits (shifted right by 22)
@@ -84,7 +83,7 @@ func (p *TSIDParser) Parse(input string) (*types.IDInfo, error) {
 		"format":              "Time-Sorted Unique Identifier",
 		"specification":       "https://github.com/rushysloth/go-tsid",
 		"timestamp_precision": "millisecond",
-		"epoch":               "2020-01-01T00:00:00Z (default)",
+		"epoch":               time.UnixMilli(tsid.TSID_EPOCH).UTC().Format(time.RFC3339) + " (default)",
 		"sortable":            "Yes (by generation time)",
 		"structure":           "42-bit timestamp + 22-bit random",
 		"timestamp_bits":      "42",
@@ -99,11 +98,11 @@ func (p *TSIDParser) Parse(input string) (*types.IDInfo, error) {
 
 	return &types.IDInfo{
 		IDType:    "TSID (Time-Sorted Unique Identifier)",
-		Standard:  strings.ToUpper(input), // Normalize to uppercase
-		Size:      64,                     // 64-bit identifier
+		Standard:  crockfordEncodeInt(new(big.Int).SetUint64(number), 13), // Canonical uppercase, aliases resolved
+		Size:      64,                                                     // 64-bit identifier
 		Entropy:   &entropy,
 		Hex:       fmt.Sprintf("%016x", number),
-		Binary:    binary,
+		Binary:    raw,
 		Integer:   &intStr,
 		DateTime:  &timestamp,
 		Timestamp: &timestampStr,
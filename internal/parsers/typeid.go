@@ -2,7 +2,9 @@ package parsers
 
 import (
 	"fmt"
+	"math/big"
 	"strings"
+	"time"
 
 	"github.com/zcyc/idinfo/internal/types"
 	"go.jetify.com/typeid/v2"
@@ -42,22 +44,39 @@ func (p *TypeIDParser) Parse(input string) (*types.IDInfo, error) {
 	// Extract UUID from the TypeID
 	uuidStr := tid.UUID()
 
-	// TypeID doesn't expose timestamp extraction directly
-	// We'll use a placeholder for now
-	timestampStr := "N/A"
+	// The suffix is the UUID in Crockford Base32
+	uuidBytes, err := crockfordDecodeBytes(suffix, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TypeID suffix: %v", err)
+	}
+	intStr := new(big.Int).SetBytes(uuidBytes).String()
+
+	// TypeIDs are generated from UUIDv7, whose first 48 bits are Unix milliseconds
+	var dateTime *time.Time
+	var timestamp *string
+	timestampStr := "N/A (not a UUIDv7)"
+	if uuidBytes[6]>>4 == 7 {
+		ms := int64(uuidBytes[0])<<40 | int64(uuidBytes[1])<<32 | int64(uuidBytes[2])<<24 |
+			int64(uuidBytes[3])<<16 | int64(uuidBytes[4])<<8 | int64(uuidBytes[5])
+		t := time.UnixMilli(ms).UTC()
+		timestampStr = fmt.Sprintf("%d", ms)
+		ts := fmt.Sprintf("%.3f", float64(ms)/1000)
+		dateTime, timestamp = &t, &ts
+	}
 
 	entropy := 128 // TypeID has same entropy as ULID (128 bits)
 
 	extra := map[string]string{
-		"type_prefix":   typePrefix,
-		"suffix":        suffix,
-		"uuid":          uuidStr,
-		"format":        "TypeID (type prefix + ULID)",
-		"specification": "https://github.com/jetify-com/typeid",
-		"alphabet":      "Crockford Base32",
-		"timestamp_ms":  timestampStr,
-		"sortable":      "Yes (chronologically sortable)",
-		"url_safe":      "Yes",
+		"type_prefix":         typePrefix,
+		"suffix":              suffix,
+		"uuid":                uuidStr,
+		"format":              "TypeID (type prefix + ULID)",
+		"specification":       "https://github.com/jetify-com/typeid",
+		"alphabet":            "Crockford Base32",
+		"timestamp_ms":        timestampStr,
+		"timestamp_precision": "millisecond",
+		"sortable":            "Yes (chronologically sortable)",
+		"url_safe":            "Yes",
 	}
 
 	// Add information about the type
@@ -86,16 +105,15 @@ func (p *TypeIDParser) Parse(input string) (*types.IDInfo, error) {
 		extra["type_description"] = description
 	}
 
-	// Convert UUID string to bytes
-	uuidBytes := []byte(uuidStr)
-
 	return &types.IDInfo{
 		IDType:    "TypeID",
 		Standard:  input,
 		Size:      128, // Same as ULID
 		Entropy:   &entropy,
-		Timestamp: &timestampStr,
-		DateTime:  nil, // TypeID doesn't expose timestamp extraction
+		Integer:   &intStr,
+		Timestamp: timestamp,
+		DateTime:  dateTime,
+		UUIDWrap:  &uuidStr,
 		Hex:       fmt.Sprintf("%x", uuidBytes),
 		Binary:    uuidBytes,
 		Extra:     extra,
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/oklog/ulid/v2"
@@ -15,6 +16,9 @@ type ULIDParser struct{}
 
 var ulidRegex = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Z]{25}$`)
 
+// Lowercase ULIDs are canonical too; aliased (I, L, O) or hyphenated forms need -f ulid
+var ulidLowerRegex = regexp.MustCompile(`^[0-7][0-9a-hjkmnp-tv-z]{25}$`)
+
 func (p *ULIDParser) Name() string {
 	return "ULID"
 }
@@ -23,14 +27,24 @@ func (p *ULIDParser) CanParse(input string) bool {
 	if len(input) != 26 {
 		return false
 	}
-	return ulidRegex.MatchString(input)
+	return ulidRegex.MatchString(input) || ulidLowerRegex.MatchString(input)
 }
 
 func (p *ULIDParser) Parse(input string) (*types.IDInfo, error) {
-	u, err := ulid.Parse(input)
+	input = strings.TrimSpace(input)
+	normalized, aliased, err := crockfordNormalize(input)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid ULID: %v", err)
+	}
+	if len(normalized) != 26 {
+		return nil, fmt.Errorf("invalid ULID: expected 26 characters, got %d", len(normalized))
 	}
+	raw, err := crockfordDecodeBytes(normalized, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ULID: %v", err)
+	}
+	var u ulid.ULID
+	copy(u[:], raw)
 
 	info := &types.IDInfo{
 		IDType:   "ULID (Universally Unique Lexicographically Sortable Identifier)",
@@ -62,6 +76,9 @@ func (p *ULIDParser) Parse(input string) (*types.IDInfo, error) {
 	info.Extra["encoding"] = "Crockford Base32"
 	info.Extra["timestamp_precision"] = "millisecond"
 	info.Extra["sortable"] = "true"
+	if aliased && strings.ToUpper(input) != normalized {
+		info.Extra["normalized"] = "hyphens removed and I/L/O aliases resolved"
+	}
 
 	return info, nil
 }
@@ -1,9 +1,14 @@
 package parsers
 
 import (
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"math"
 	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/zcyc/idinfo/internal/types"
@@ -11,87 +16,135 @@ import (
 
 type UnixTimeParser struct{}
 
-var unixTimeRegex = regexp.MustCompile(`^(0|\d{10,19})$`)
+var (
+	// Integers of 9-19 digits (or 0), optionally negative and with a fraction of the unit
+	unixTimeRegex = regexp.MustCompile(`^-?(0|\d{9,19})(\.\d{1,9})?$`)
+	// Excel serial dates: 5-digit day numbers (1927-2173) with an optional time of day
+	excelSerialRegex = regexp.MustCompile(`^\d{5}(\.\d{1,9})?$`)
+	// 32-bit or 64-bit values written in hex
+	unixTimeHexRegex = regexp.MustCompile(`^0[xX]([0-9a-fA-F]{8}|[0-9a-fA-F]{16})$`)
+)
+
+// timeEpoch is one way of counting time from an epoch
+type timeEpoch struct {
+	name  string
+	short string // label that fits the card
+	unit  string
+	epoch time.Time
+	// unitNanos is the length of one unit
+	unitNanos uint64
+	// prior weighs how common the encoding is against the others
+	prior    float64
+	negative bool // whether negative counts are meaningful
+	bits32   bool // whether 32-bit hex values use it
+}
+
+// gpsLeapSeconds is how far GPS time has run ahead of UTC since 2017
+const gpsLeapSeconds = 18
+
+var timeEpochs = []timeEpoch{
+	{"Unix seconds", "Unix s", "seconds", time.Unix(0, 0).UTC(), 1e9, 1, true, true},
+	{"Unix milliseconds", "Unix ms", "milliseconds", time.Unix(0, 0).UTC(), 1e6, 1, true, false},
+	{"Unix microseconds", "Unix us", "microseconds", time.Unix(0, 0).UTC(), 1e3, 0.7, true, false},
+	{"Unix nanoseconds", "Unix ns", "nanoseconds", time.Unix(0, 0).UTC(), 1, 0.7, true, false},
+	{"Windows FILETIME", "FILETIME", "100-nanosecond intervals", time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC), 100, 0.5, false, false},
+	{".NET ticks", ".NET ticks", "100-nanosecond ticks", time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), 100, 0.5, false, false},
+	{"Chrome/WebKit", "WebKit", "microseconds", time.Date(1601, 1, 1, 0, 0, 0, 0, time.UTC), 1e3, 0.4, false, false},
+	{"Apple Cocoa", "Cocoa", "seconds", time.Date(2001, 1, 1, 0, 0, 0, 0, time.UTC), 1e9, 0.2, true, true},
+	{"GPS", "GPS", "seconds", time.Date(1980, 1, 6, 0, 0, -gpsLeapSeconds, 0, time.UTC), 1e9, 0.2, true, true},
+	{"NTP", "NTP", "seconds", time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC), 1e9, 0.2, false, true},
+	{"Excel serial date", "Excel", "days", time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC), 86400e9, 0.3, false, false},
+}
 
 func (p *UnixTimeParser) Name() string {
 	return "UnixTime"
 }
 
 func (p *UnixTimeParser) CanParse(input string) bool {
-	if !unixTimeRegex.MatchString(input) {
-		return false
+	_, _, _, _, err := parseTimeNumber(input)
+	return err == nil
+}
+
+// parseTimeNumber splits a timestamp into its magnitude, sign and fraction of a unit, and
+// reports the width in bits of a hex value (0 for decimals)
+func parseTimeNumber(input string) (magnitude uint64, negative bool, fraction float64, hexBits int, err error) {
+	if m := unixTimeHexRegex.FindStringSubmatch(input); m != nil {
+		magnitude, err = strconv.ParseUint(m[1], 16, 64)
+		return magnitude, false, 0, len(m[1]) * 4, err
+	}
+	if !unixTimeRegex.MatchString(input) && !excelSerialRegex.MatchString(input) {
+		return 0, false, 0, 0, fmt.Errorf("invalid timestamp format")
+	}
+	integer, frac, _ := strings.Cut(input, ".")
+	negative = strings.HasPrefix(integer, "-")
+	magnitude, err = strconv.ParseUint(strings.TrimPrefix(integer, "-"), 10, 64)
+	if err != nil {
+		return 0, false, 0, 0, err
 	}
+	if negative && magnitude > math.MaxInt64 {
+		return 0, false, 0, 0, fmt.Errorf("timestamp out of range")
+	}
+	if frac != "" {
+		fraction, _ = strconv.ParseFloat("0."+frac, 64)
+	}
+	return magnitude, negative, fraction, 0, nil
+}
 
-	// Must be a valid integer (use uint64 for large numbers)
-	_, err := strconv.ParseUint(input, 10, 64)
+// isTimeNumberForm reports whether input is a timestamp only UnixTime reads in full: hex with 0x,
+// negative or with a fraction. Geohash, Sqids and NanoID would otherwise claim it first.
+func isTimeNumberForm(input string) bool {
+	if !strings.HasPrefix(strings.ToLower(input), "0x") && !strings.HasPrefix(input, "-") && !strings.Contains(input, ".") {
+		return false
+	}
+	_, _, _, _, err := parseTimeNumber(input)
 	return err == nil
 }
 
 func (p *UnixTimeParser) Parse(input string) (*types.IDInfo, error) {
-	timestampUint, err := strconv.ParseUint(input, 10, 64)
+	input = strings.TrimSpace(input)
+	magnitude, negative, fraction, hexBits, err := parseTimeNumber(input)
 	if err != nil {
 		return nil, err
 	}
 
-	// For compatibility with time functions, try to convert to signed
-	// For very large values, we may lose precision but still provide info
-	timestamp := int64(timestampUint)
-	if timestampUint > 9223372036854775807 {
-		// For values that don't fit in int64, we'll still process them
-		// but the time interpretation may be limited
-		timestamp = int64(timestampUint & 0x7FFFFFFFFFFFFFFF)
-	}
-
-	// Determine the most likely unit based on the magnitude
-	var t time.Time
-	var unit string
-	var precision string
-
-	now := time.Now()
-
-	// Try different interpretations
-	candidates := []struct {
-		time      time.Time
-		unit      string
-		precision string
-	}{
-		{time.Unix(timestamp, 0), "seconds", "second"},
-		{time.UnixMilli(timestamp), "milliseconds", "millisecond"},
-		{time.UnixMicro(timestamp), "microseconds", "microsecond"},
-		{time.Unix(0, timestamp), "nanoseconds", "nanosecond"},
-	}
-
-	// Find the most reasonable interpretation (closest to current time)
-	minDiff := int64(1<<63 - 1)
-	for _, candidate := range candidates {
-		if candidate.time.Year() >= 1970 && candidate.time.Year() <= 2100 {
-			diff := absInt64(candidate.time.Unix() - now.Unix())
-			if diff < minDiff {
-				minDiff = diff
-				t = candidate.time
-				unit = candidate.unit
-				precision = candidate.precision
-			}
-		}
+	interpretations := timeInterpretations(magnitude, negative, fraction, hexBits, time.Now())
+	if len(interpretations) == 0 {
+		return nil, fmt.Errorf("no epoch gives a representable time for %s", input)
 	}
+	best := interpretations[0]
 
-	// Default to seconds if no good match
-	if t.IsZero() {
-		t = time.Unix(timestamp, 0)
-		unit = "seconds"
-		precision = "second"
+	// Big-endian bytes of the integer part: 32 bits for 8-digit hex, otherwise a 64-bit two's complement
+	value := magnitude
+	if negative {
+		value = uint64(-int64(magnitude))
+	}
+	size := 64
+	raw := make([]byte, 8)
+	binary.BigEndian.PutUint64(raw, value)
+	if hexBits == 32 {
+		size, raw = 32, raw[4:]
+	}
+	integer := strconv.FormatUint(magnitude, 10)
+	if negative {
+		integer = "-" + integer
 	}
 
+	idType := fmt.Sprintf("Timestamp (%s)", best.Name)
+	if strings.HasPrefix(best.Name, "Unix ") {
+		idType = fmt.Sprintf("Unix timestamp (%s)", best.Unit)
+	}
 	info := &types.IDInfo{
-		IDType:   fmt.Sprintf("Unix timestamp (%s)", unit),
-		Standard: input,
-		Size:     64, // Assuming 64-bit timestamp
-		Hex:      fmt.Sprintf("%016x", uint64(timestamp)),
-		Binary:   []byte(fmt.Sprintf("%064b", timestamp)),
-		Extra:    make(map[string]string),
+		IDType:          idType,
+		Standard:        input,
+		Size:            size,
+		Integer:         &integer,
+		Hex:             hex.EncodeToString(raw),
+		Binary:          raw,
+		Interpretations: interpretations,
+		Extra:           make(map[string]string),
 	}
 
-	info.Integer = &input
+	t := best.DateTime
 	info.DateTime = &t
 	timestampStr := fmt.Sprintf("%.3f", float64(t.UnixMilli())/1000)
 	info.Timestamp = &timestampStr
@@ -100,22 +153,138 @@ func (p *UnixTimeParser) Parse(input string) (*types.IDInfo, error) {
 	entropy := 0
 	info.Entropy = &entropy
 
-	// Add Unix timestamp-specific information
-	info.Extra["unit"] = unit
-	info.Extra["precision"] = precision
-	info.Extra["epoch"] = "1970-01-01T00:00:00Z"
+	info.Extra["unit"] = best.Unit
+	info.Extra["precision"] = timePrecision(best.Name)
+	info.Extra["epoch"] = best.Epoch
+	info.Extra["plausibility"] = FormatPercent(best.Score)
 	info.Extra["deterministic"] = "true"
-
+	if best.Name == "Unix seconds" {
+		if seconds := t.Unix(); seconds > math.MaxInt32 || seconds < math.MinInt32 {
+			info.Extra["time_t_32bit"] = "overflows a signed 32-bit time_t (2038-01-19T03:14:07Z)"
+		} else {
+			info.Extra["time_t_32bit"] = "fits a signed 32-bit time_t"
+		}
+	}
 	return info, nil
 }
 
-func (p *UnixTimeParser) Generate() (string, error) {
-	return fmt.Sprintf("%d", time.Now().Unix()), nil
+// timeInterpretations reads a number against every epoch and unit that can represent it, scored
+// by how close to now the result is and how common the encoding is. Scores add up to 1 and the
+// most plausible reading comes first. Zero is the epoch of every encoding, so it is only read as
+// the Unix epoch.
+func timeInterpretations(magnitude uint64, negative bool, fraction float64, hexBits int, now time.Time) []types.TimeInterpretation {
+	var result []types.TimeInterpretation
+	total := 0.0
+	for _, e := range timeEpochs {
+		if negative && !e.negative || hexBits == 32 && !e.bits32 {
+			continue
+		}
+		if magnitude == 0 && fraction == 0 && e.name != "Unix seconds" {
+			continue
+		}
+		var t time.Time
+		var ok bool
+		switch {
+		case e.name == "NTP" && magnitude > math.MaxUint32:
+			// 64-bit NTP: 32 bits of seconds and 32 bits of fraction
+			t, ok = epochOffset(e.epoch, magnitude>>32, false, float64(magnitude&math.MaxUint32)/(1<<32), 1e9)
+		case e.name == "Excel serial date" && magnitude < 61:
+			// Excel counts the non-existent 1900-02-29, so serials before it are a day later
+			t, ok = epochOffset(e.epoch.AddDate(0, 0, 1), magnitude, false, fraction, e.unitNanos)
+			ok = ok && magnitude >= 1
+		case e.name == "Excel serial date":
+			t, ok = epochOffset(e.epoch, magnitude, false, fraction, e.unitNanos)
+		default:
+			t, ok = epochOffset(e.epoch, magnitude, negative, fraction, e.unitNanos)
+		}
+		if !ok {
+			continue
+		}
+
+		score := e.prior * timePlausibility(t, now)
+		total += score
+		result = append(result, types.TimeInterpretation{
+			Name:     e.name,
+			Short:    e.short,
+			Unit:     e.unit,
+			Epoch:    e.epoch.Format(time.RFC3339),
+			DateTime: t,
+			Score:    score,
+		})
+	}
+	for i := range result {
+		if total > 0 {
+			result[i].Score /= total
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Score > result[j].Score
+	})
+	return result
 }
 
-func absInt64(x int64) int64 {
-	if x < 0 {
-		return -x
+// epochOffset adds a number of units to an epoch, reporting false when the result falls outside
+// years 1-9999
+func epochOffset(epoch time.Time, magnitude uint64, negative bool, fraction float64, unitNanos uint64) (time.Time, bool) {
+	var seconds, nanos int64
+	if unitNanos >= 1e9 {
+		perUnit := unitNanos / 1e9
+		if magnitude > uint64(1e13)/perUnit {
+			return time.Time{}, false
+		}
+		seconds = int64(magnitude * perUnit)
+	} else {
+		perSecond := 1e9 / unitNanos
+		seconds = int64(magnitude / perSecond)
+		nanos = int64(magnitude%perSecond) * int64(unitNanos)
+	}
+	if seconds > 1e13 {
+		return time.Time{}, false
+	}
+	nanos += int64(math.Round(fraction * float64(unitNanos)))
+	if negative {
+		seconds, nanos = -seconds, -nanos
+	}
+	t := time.Unix(epoch.Unix()+seconds, nanos).UTC()
+	if t.Year() < 1 || t.Year() > 9999 {
+		return time.Time{}, false
 	}
-	return x
+	return t, true
+}
+
+// timePlausibility falls off with the distance from now in decades, and halves again for times
+// more than a year ahead
+func timePlausibility(t, now time.Time) float64 {
+	years := math.Abs(float64(t.Unix()-now.Unix())) / secondsPerYear
+	score := 1 / (1 + (years/10)*(years/10))
+	if t.After(now.AddDate(1, 0, 0)) {
+		score /= 2
+	}
+	return score
+}
+
+// timePrecision names the resolution of an encoding
+func timePrecision(name string) string {
+	for _, e := range timeEpochs {
+		if e.name != name {
+			continue
+		}
+		switch e.unitNanos {
+		case 86400e9:
+			return "day"
+		case 1e9:
+			return "second"
+		case 1e6:
+			return "millisecond"
+		case 1e3:
+			return "microsecond"
+		case 100:
+			return "100 nanoseconds"
+		}
+	}
+	return "nanosecond"
+}
+
+func (p *UnixTimeParser) Generate() (string, error) {
+	return fmt.Sprintf("%d", time.Now().Unix()), nil
 }
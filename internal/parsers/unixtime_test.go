@@ -38,12 +38,13 @@ func TestUnixTimeParser_CanParse(t *testing.T) {
 	// Test invalid timestamps
 	invalidTimestamps := []string{
 		"",                          // empty
-		"123456789",                 // too short (9 digits)
+		"12345678",                  // too short (8 digits)
 		"12345678901234567890123",   // too long (20+ digits)
 		"abc123456789",              // contains letters
-		"123456789.0",               // contains decimal point
+		"12345678.0",                // too short, with a fraction
+		"1234567890.",               // decimal point without a fraction
 		"123456789-",                // contains hyphen
-		"-123456789",                // negative number (not supported here)  
+		"-12345678",                 // negative, too short
 		"12 3456789",                // contains space
 		"123456789a",                // ends with letter
 		"0x123456789",               // hex prefix
@@ -161,7 +162,10 @@ func TestUnixTimeParser_Generate(t *testing.T) {
 	
 	// Check it's a reasonable current timestamp (within 1 minute of now)
 	now := time.Now().Unix()
-	diff := absInt64(generatedInt - now)
+	diff := generatedInt - now
+	if diff < 0 {
+		diff = -diff
+	}
 	if diff > 60 { // Allow 1 minute difference
 		t.Errorf("Generated timestamp should be close to current time, got: %d, now: %d, diff: %d", 
 			generatedInt, now, diff)
@@ -229,7 +233,8 @@ func TestUnixTimeParser_EdgeCases(t *testing.T) {
 	}{
 		{"0", true, "epoch timestamp"},
 		{"1000000000", true, "10 digits seconds timestamp"},
-		{"999999999", false, "9 digits (too short)"},
+		{"99999999", false, "8 digits (too short)"},
+		{"999999999", true, "9 digits (Apple Cocoa seconds)"},
 		{"9999999999999999999", true, "19 digits (max length)"},
 		{"10000000000000000000", false, "20 digits (too long)"},
 		{"2147483647", true, "max 32-bit signed int"},
@@ -259,26 +264,6 @@ func TestUnixTimeParser_EdgeCases(t *testing.T) {
 	}
 }
 
-func TestUnixTimeParser_HelperFunctions(t *testing.T) {
-	// Test absInt64 function
-	tests := []struct {
-		input    int64
-		expected int64
-	}{
-		{5, 5},
-		{-5, 5},
-		{0, 0},
-		{1<<63 - 1, 1<<63 - 1}, // max int64
-	}
-	
-	for _, test := range tests {
-		result := absInt64(test.input)
-		if result != test.expected {
-			t.Errorf("absInt64(%d) = %d, expected %d", test.input, result, test.expected)
-		}
-	}
-}
-
 func TestUnixTimeParser_ReasonableTimeDetection(t *testing.T) {
 	parser := &UnixTimeParser{}
 	
@@ -341,4 +326,117 @@ func TestUnixTimeParser_Performance(t *testing.T) {
 			t.Fatalf("Generate failed during performance test: %v", err)
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestUnixTimeParser_AlternativeEpochs(t *testing.T) {
+	parser := &UnixTimeParser{}
+	instant := time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC)
+
+	testCases := []struct {
+		input    string
+		best     string
+		expected time.Time
+	}{
+		{"1700000000.123", "Unix seconds", instant.Add(123 * time.Millisecond)},
+		{"0x6553f100", "Unix seconds", instant},
+		{"133444736000000000", "Windows FILETIME", instant},
+		{"638355968000000000", ".NET ticks", instant},
+		{"13344473600000000", "Chrome/WebKit", instant},
+		{"721692800", "Apple Cocoa", instant},
+		{"3908988800", "NTP", instant},
+		{"0xe8fe6f8020000000", "NTP", instant.Add(125 * time.Millisecond)},
+		{"45352.5", "Excel serial date", time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range testCases {
+		info, err := parser.Parse(tc.input)
+		if err != nil {
+			t.Errorf("Parse(%s) failed: %v", tc.input, err)
+			continue
+		}
+		best := info.Interpretations[0]
+		if best.Name != tc.best {
+			t.Errorf("%s: expected %s to be most plausible, got %s", tc.input, tc.best, best.Name)
+		}
+		if !info.DateTime.Equal(tc.expected) || !best.DateTime.Equal(tc.expected) {
+			t.Errorf("%s: expected %s, got %s", tc.input, tc.expected, info.DateTime)
+		}
+	}
+}
+
+func TestUnixTimeParser_Interpretations(t *testing.T) {
+	parser := &UnixTimeParser{}
+	info, err := parser.Parse("1700000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0.0
+	found := map[string]time.Time{}
+	for i, reading := range info.Interpretations {
+		total += reading.Score
+		found[reading.Name] = reading.DateTime
+		if i > 0 && reading.Score > info.Interpretations[i-1].Score {
+			t.Errorf("Interpretations are not ordered by score: %s after %s", reading.Name, info.Interpretations[i-1].Name)
+		}
+	}
+	if total < 0.999 || total > 1.001 {
+		t.Errorf("Expected scores to add up to 1, got %f", total)
+	}
+	// GPS runs 18 leap seconds ahead of UTC
+	if gps, ok := found["GPS"]; !ok || !gps.Equal(time.Date(2033, 11, 18, 22, 13, 20-gpsLeapSeconds, 0, time.UTC)) {
+		t.Errorf("Expected a GPS reading in 2033, got %v", gps)
+	}
+	if info.Extra["time_t_32bit"] != "fits a signed 32-bit time_t" {
+		t.Errorf("Unexpected 32-bit note: %s", info.Extra["time_t_32bit"])
+	}
+
+	// A zero timestamp is only the Unix epoch
+	info, _ = parser.Parse("0")
+	if len(info.Interpretations) != 1 || !info.DateTime.Equal(time.Unix(0, 0)) {
+		t.Errorf("Expected 0 to be read only as the Unix epoch, got %d readings", len(info.Interpretations))
+	}
+}
+
+func TestUnixTimeParser_NegativeAndBinary(t *testing.T) {
+	parser := &UnixTimeParser{}
+
+	info, err := parser.Parse("-1000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seconds := false
+	for _, reading := range info.Interpretations {
+		if reading.Name == "Unix seconds" {
+			seconds = reading.DateTime.Equal(time.Date(1938, 4, 24, 22, 13, 20, 0, time.UTC))
+		}
+		if reading.Name == "Windows FILETIME" || reading.Name == "Excel serial date" {
+			t.Errorf("Unsigned encoding %s should not read a negative value", reading.Name)
+		}
+	}
+	if !seconds {
+		t.Error("Expected a Unix seconds reading in 1938")
+	}
+	// Two's complement, big-endian
+	if info.Hex != "ffffffffc4653600" || len(info.Binary) != 8 || info.Binary[0] != 0xff || info.Binary[7] != 0x00 {
+		t.Errorf("Unexpected bytes: %s %x", info.Hex, info.Binary)
+	}
+
+	info, _ = parser.Parse("1609459200")
+	if info.Hex != "000000005fee6600" || info.Binary[4] != 0x5f {
+		t.Errorf("Expected big-endian bytes of the value, got %s %x", info.Hex, info.Binary)
+	}
+
+	info, _ = parser.Parse("0x5fee6600")
+	if info.Size != 32 || len(info.Binary) != 4 || *info.Integer != "1609459200" {
+		t.Errorf("Expected a 32-bit value 1609459200, got %d bits, %v", info.Size, *info.Integer)
+	}
+}
+
+func TestUnixTimeParser_AutoDetect(t *testing.T) {
+	// Hex, negative and fractional timestamps are only read in full as timestamps
+	for _, input := range []string{"0x6553f100", "-1700000000", "1700000000.25"} {
+		results := ParseID(input, "")
+		if len(results) == 0 || !strings.HasPrefix(results[0].IDType, "Unix timestamp") {
+			t.Errorf("Expected %s to be detected as a Unix timestamp, got %v", input, results)
+		}
+	}
+}
@@ -68,13 +68,16 @@ func (p *UUIDParser) Parse(input string) (*types.IDInfo, error) {
 	switch version {
 	case 1:
 		info.Version = "1 (timestamp and MAC address)"
-		timestamp := int64(u.Time())
+		// The timestamp counts 100-nanosecond intervals since the Gregorian calendar reform
+		sec, nsec := u.Time().UnixTime()
 		clockSeq := u.ClockSequence()
 		node := u.NodeID()
-		t := time.Unix(0, timestamp)
+		t := time.Unix(sec, nsec)
 		info.DateTime = &t
-		timestampStr := fmt.Sprintf("%.3f", float64(timestamp)/1e9)
+		timestampStr := fmt.Sprintf("%.3f", float64(t.UnixNano())/1e9)
 		info.Timestamp = &timestampStr
+		info.Extra["epoch"] = "1582-10-15T00:00:00Z"
+		info.Extra["timestamp_precision"] = "100 nanoseconds"
 
 		seq := int64(clockSeq)
 		info.Sequence = &seq
@@ -116,6 +119,7 @@ func (p *UUIDParser) Parse(input string) (*types.IDInfo, error) {
 		info.DateTime = &t
 		timestampStr := fmt.Sprintf("%.3f", float64(timestampMs)/1000)
 		info.Timestamp = &timestampStr
+		info.Extra["timestamp_precision"] = "millisecond"
 		entropy := 74 // 74 bits of randomness
 		info.Entropy = &entropy
 
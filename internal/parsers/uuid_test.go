@@ -107,6 +107,20 @@ func TestUUIDParser_Parse(t *testing.T) {
 	}
 }
 
+func TestUUIDParser_ParseV1Epoch(t *testing.T) {
+	// v1 counts 100ns intervals from 1582-10-15, not from the Unix epoch
+	info, err := (&UUIDParser{}).Parse("c6c9b9c0-5b2e-11ee-8c99-0242ac120002")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if info.DateTime == nil || info.DateTime.Unix() != 1695596695 {
+		t.Errorf("Expected timestamp 1695596695 (2023-09-24T23:04:55Z), got %v", info.DateTime)
+	}
+	if info.Extra["epoch"] != "1582-10-15T00:00:00Z" {
+		t.Errorf("Expected the Gregorian epoch, got %q", info.Extra["epoch"])
+	}
+}
+
 func TestUUIDParser_Generate(t *testing.T) {
 	parser := &UUIDParser{}
 	
@@ -83,6 +83,7 @@ func (p *XidParser) Parse(input string) (*types.IDInfo, error) {
 	info.Extra["machine_bytes"] = machineId
 	info.Extra["process_bytes"] = processId
 	info.Extra["counter_value"] = fmt.Sprintf("%d", counter)
+	info.Extra["counter_bits"] = "24"
 	info.Extra["sortable"] = "true"
 
 	return info, nil
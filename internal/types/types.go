@@ -20,7 +20,213 @@ type IDInfo struct {
 	Node2     *string           `json:"node2,omitempty"`
 	Hex       string            `json:"hex"`
 	Binary    []byte            `json:"-"`
+	Secret    bool              `json:"secret,omitempty"`
 	Extra     map[string]string `json:"extra,omitempty"`
+	Nested    []NestedID        `json:"nested,omitempty"`
+	Collision *CollisionRisk    `json:"collision,omitempty"`
+
+	// Interpretations lists every epoch a bare timestamp could count from, most plausible first
+	Interpretations []TimeInterpretation `json:"interpretations,omitempty"`
+}
+
+// CollisionRisk is a birthday-bound estimate of duplicate IDs for a number of random bits
+type CollisionRisk struct {
+	RandomBits         int      `json:"random_bits"`
+	Threshold          float64  `json:"threshold"`
+	IDsAtThreshold     float64  `json:"ids_at_threshold"`
+	Summary            string   `json:"summary"`
+	Count              float64  `json:"count,omitempty"`
+	Probability        *float64 `json:"probability,omitempty"`
+	RatePerSecond      float64  `json:"rate_per_second,omitempty"`
+	PeriodSeconds      float64  `json:"period_seconds,omitempty"`
+	SecondsToThreshold float64  `json:"seconds_to_threshold,omitempty"`
+	// Tick is set for time-based IDs, which only collide within one timestamp tick; IDsAtThreshold
+	// is then per tick
+	Tick        string  `json:"tick,omitempty"`
+	TickSeconds float64 `json:"tick_seconds,omitempty"`
+}
+
+// NestedID is an ID found inside another one, such as a JWT claim
+type NestedID struct {
+	Field string  `json:"field"`
+	Value string  `json:"value"`
+	Info  *IDInfo `json:"info"`
+}
+
+// CursorNode is one value of a decoded pagination cursor or opaque token
+type CursorNode struct {
+	Key      string        `json:"key,omitempty"`
+	Kind     string        `json:"kind"`
+	Value    string        `json:"value,omitempty"`
+	Layers   []string      `json:"layers,omitempty"`
+	IDs      []NestedID    `json:"ids,omitempty"`
+	Children []*CursorNode `json:"children,omitempty"`
+}
+
+// CorpusAnalysis holds randomness measurements over many IDs of one format
+type CorpusAnalysis struct {
+	Format              string         `json:"format"`
+	Count               int            `json:"count"`
+	Skipped             int            `json:"skipped,omitempty"`
+	Duplicates          int            `json:"duplicates"`
+	DeclaredEntropy     *int           `json:"declared_entropy,omitempty"`
+	MeasuredBitEntropy  float64        `json:"measured_bit_entropy"`
+	MeasuredCharEntropy float64        `json:"measured_char_entropy"`
+	Findings            []string       `json:"findings,omitempty"`
+	Bits                []BitStat      `json:"bits,omitempty"`
+	Positions           []PositionStat `json:"positions,omitempty"`
+}
+
+// BitStat measures one bit position of the decoded IDs, counting from the most significant bit
+type BitStat struct {
+	Position    int     `json:"position"`
+	Ones        float64 `json:"ones"`
+	Entropy     float64 `json:"entropy"`
+	BiasPValue  float64 `json:"bias_p_value"`
+	RunsZ       float64 `json:"runs_z"`
+	RunsPValue  float64 `json:"runs_p_value"`
+	Fixed       bool    `json:"fixed,omitempty"`
+	SampleCount int     `json:"sample_count"`
+}
+
+// PositionStat measures one character position of the IDs' string form
+type PositionStat struct {
+	Position         int     `json:"position"`
+	Distinct         int     `json:"distinct"`
+	Entropy          float64 `json:"entropy"`
+	Fixed            string  `json:"fixed,omitempty"` // the character of a position that never varies, unless the IDs are secrets
+	Restricted       bool    `json:"restricted,omitempty"`
+	ChiSquare        float64 `json:"chi_square,omitempty"`
+	DegreesOfFreedom int     `json:"degrees_of_freedom,omitempty"`
+	PValue           float64 `json:"p_value,omitempty"`
+}
+
+// FleetReport describes the generators seen in a stream of IDs
+type FleetReport struct {
+	Format     string           `json:"format"`
+	Count      int              `json:"count"`
+	Skipped    int              `json:"skipped,omitempty"`
+	Findings   []string         `json:"findings,omitempty"`
+	Generators []*GeneratorStat `json:"generators"`
+}
+
+// GeneratorStat summarizes the IDs from one machine, process or node
+type GeneratorStat struct {
+	Node1           string     `json:"node1,omitempty"`
+	Node2           string     `json:"node2,omitempty"`
+	Count           int        `json:"count"`
+	FirstSeen       *time.Time `json:"first_seen,omitempty"`
+	LastSeen        *time.Time `json:"last_seen,omitempty"`
+	RatePerSecond   float64    `json:"rate_per_second,omitempty"`
+	CounterConstant bool       `json:"counter_constant,omitempty"`
+	// CounterStep is set for counters that do not step by 1, whose gaps are not counted
+	CounterStep    string   `json:"counter_step,omitempty"`
+	CounterGaps    int      `json:"counter_gaps"`
+	MissingValues  int64    `json:"missing_values"`
+	CounterResets  int      `json:"counter_resets"`
+	CounterRepeats int      `json:"counter_repeats"`
+	ClockSkewMs    *float64 `json:"clock_skew_ms,omitempty"`
+}
+
+// AnomalyReport lists the data-quality problems found in a stream of IDs
+type AnomalyReport struct {
+	Format   string         `json:"format"`
+	Count    int            `json:"count"`
+	Counts   map[string]int `json:"counts"`
+	Findings []Anomaly      `json:"findings"`
+}
+
+// Anomaly is one problem found at a line of the input
+type Anomaly struct {
+	Line     int        `json:"line"`
+	Kind     string     `json:"kind"`
+	ID       string     `json:"id"`
+	Message  string     `json:"message"`
+	DateTime *time.Time `json:"datetime,omitempty"`
+	// Timestamp holds the time as text; DateTime is left out when the year is outside 0-9999,
+	// which JSON cannot encode
+	Timestamp   string `json:"timestamp,omitempty"`
+	RelatedLine int    `json:"related_line,omitempty"`
+	Generator   string `json:"generator,omitempty"`
+}
+
+// IDDiff aligns the fields of several IDs and relates each one to the first
+type IDDiff struct {
+	IDs       []*IDInfo    `json:"ids"`
+	Fields    []FieldDiff  `json:"fields"`
+	Relations []IDRelation `json:"relations"`
+}
+
+// FieldDiff holds one field's value in each ID
+type FieldDiff struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+	Same   bool     `json:"same"`
+}
+
+// IDRelation describes how the ID at Index relates to the first ID
+type IDRelation struct {
+	Index           int      `json:"index"`
+	Summary         string   `json:"summary"`
+	TimeDeltaMs     *float64 `json:"time_delta_ms,omitempty"`
+	SameNode1       *bool    `json:"same_node1,omitempty"`
+	SameNode2       *bool    `json:"same_node2,omitempty"`
+	CounterDistance *int64   `json:"counter_distance,omitempty"`
+	DifferingBits   int      `json:"differing_bits"`
+	BitMask         []byte   `json:"-"`
+}
+
+// TimedID is an input ID with its decoded timestamp
+type TimedID struct {
+	Line     int       `json:"line"`
+	ID       string    `json:"id"`
+	Format   string    `json:"format"`
+	DateTime time.Time `json:"datetime"`
+}
+
+// Histogram counts IDs per time bucket
+type Histogram struct {
+	Bucket   string            `json:"bucket"`
+	Count    int               `json:"count"`
+	Skipped  int               `json:"skipped"`
+	Filtered int               `json:"filtered"`
+	Buckets  []HistogramBucket `json:"buckets"`
+}
+
+// HistogramBucket is the number of IDs created in the bucket starting at Start
+type HistogramBucket struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+}
+
+// TimePrefixReport lists, per time-sortable format, the IDs that could be created at one instant
+type TimePrefixReport struct {
+	At       time.Time    `json:"at"`
+	Prefixes []TimePrefix `json:"prefixes"`
+}
+
+// TimePrefix is the range of one format's IDs at an instant and the prefix they share. The
+// prefix matches IDs created in [MatchesFrom, MatchesUntil), which can be wider than one tick.
+type TimePrefix struct {
+	Format       string     `json:"format"`
+	Precision    string     `json:"precision"`
+	Prefix       string     `json:"prefix,omitempty"`
+	First        string     `json:"first,omitempty"`
+	Last         string     `json:"last,omitempty"`
+	MatchesFrom  *time.Time `json:"matches_from,omitempty"`
+	MatchesUntil *time.Time `json:"matches_until,omitempty"`
+	Error        string     `json:"error,omitempty"`
+}
+
+// TimeInterpretation is one reading of a number as a time since an epoch
+type TimeInterpretation struct {
+	Name     string    `json:"name"`
+	Short    string    `json:"-"`
+	Unit     string    `json:"unit"`
+	Epoch    string    `json:"epoch"`
+	DateTime time.Time `json:"datetime"`
+	// Score is the reading's plausibility; the scores of one number add up to 1
+	Score float64 `json:"score"`
 }
 
 // IDParser interface for all ID parsers
@@ -44,6 +250,7 @@ const (
 	FormatSCRU128   IDFormat = "scru128"
 	FormatSCRU64    IDFormat = "scru64"
 	FormatSnowflake IDFormat = "snowflake"
+	FormatSonyflake IDFormat = "sonyflake"
 	FormatTSID      IDFormat = "tsid"
 	FormatNUID      IDFormat = "nuid"
 	FormatNanoID    IDFormat = "nanoid"
@@ -55,4 +262,21 @@ const (
 	FormatShortUUID IDFormat = "shortuuid"
 	FormatSqids     IDFormat = "sqids"
 	FormatTypeID    IDFormat = "typeid"
+	FormatElastic   IDFormat = "elasticsearch"
+	FormatB58Check  IDFormat = "base58check"
+	FormatBech32    IDFormat = "bech32"
+	FormatEthereum  IDFormat = "ethereum"
+	FormatCID       IDFormat = "cid"
+	FormatGitOID    IDFormat = "gitoid"
+	FormatAWSARN    IDFormat = "awsarn"
+	FormatAWSID     IDFormat = "awsresourceid"
+	FormatAzure     IDFormat = "azure"
+	FormatGCP       IDFormat = "gcp"
+	FormatPrefixed  IDFormat = "prefixed"
+	FormatGitHub    IDFormat = "githubnodeid"
+	FormatJWT       IDFormat = "jwt"
+	FormatCrockford IDFormat = "crockford"
+	FormatGeohash   IDFormat = "geohash"
+	FormatBase64    IDFormat = "base64"
+	FormatHashids   IDFormat = "hashids"
 )
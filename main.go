@@ -5,22 +5,71 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"math"
 	"os"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/zcyc/idinfo/internal/output"
 	"github.com/zcyc/idinfo/internal/parsers"
+	"github.com/zcyc/idinfo/internal/types"
 )
 
 func main() {
+	// Subcommands take their own flags
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "collision":
+			handleCollisionCommand(os.Args[2:])
+			return
+		case "analyze":
+			handleAnalyzeCommand(os.Args[2:])
+			return
+		case "fleet":
+			handleFleetCommand(os.Args[2:])
+			return
+		case "check":
+			handleCheckCommand(os.Args[2:])
+			return
+		case "diff":
+			handleDiffCommand(os.Args[2:])
+			return
+		case "sort":
+			handleSortCommand(os.Args[2:])
+			return
+		case "histogram":
+			handleHistogramCommand(os.Args[2:])
+			return
+		case "prefix":
+			handlePrefixCommand(os.Args[2:])
+			return
+		}
+	}
+
 	var (
 		forceFormat  = flag.String("f", "", "Force parsing as specific format")
-		outputFormat = flag.String("o", "card", "Output format (card, short, json, binary)")
+		outputFormat = flag.String("o", "card", "Output format (card, short, json, yaml, toml, csv, tsv, ndjson, logfmt, binary, template=TEXT)")
+		templateFile = flag.String("template-file", "", "Render results through a Go template kept in a file")
 		everything   = flag.Bool("e", false, "Show all possible format interpretations")
 		compare      = flag.Bool("compare", false, "Compare timestamps from different formats")
+		cursor       = flag.Bool("cursor", false, "Decode a pagination cursor or opaque token")
 		generate     = flag.String("g", "", "Generate ID of specified format")
 		colorOutput  = flag.Bool("color", true, "Enable colored output")
+		gitBlob      = flag.String("git-blob", "", "Compute the Git blob object ID of a file")
+		sonyStart    = flag.String("sonyflake-start", "", "Sonyflake start time (RFC3339 or YYYY-MM-DD)")
+		sonyMachine  = flag.Int("sonyflake-machine", -1, "Sonyflake machine ID (0-65535)")
+		jwtSecret    = flag.String("jwt-secret", "", "HMAC secret for verifying JWT signatures")
+		jwks         = flag.String("jwks", "", "Local JWKS file for verifying JWT signatures")
+		reveal       = flag.Bool("reveal-secrets", false, "Show API keys and card numbers unmasked")
+		tz           = flag.String("tz", "UTC", "Time zone timestamps are shown in")
+		hashSalt     = flag.String("hashids-salt", "", "Hashids salt")
+		hashAlphabet = flag.String("hashids-alphabet", "", "Hashids alphabet")
+		hashMinLen   = flag.Int("hashids-min-length", -1, "Hashids minimum length")
+		hashProfile  = flag.String("hashids-profile", "", "Hashids profile from the profiles file")
+		hashConfig   = flag.String("hashids-config", "", "Hashids profiles file")
 		help         = flag.Bool("help", false, "Show help")
 	)
 	flag.Parse()
@@ -29,10 +78,36 @@ func main() {
 		showHelp()
 		return
 	}
+	timeZoneFlag(*tz)
+	tmpl := templateFlag(*outputFormat, *templateFile)
+
+	sonyflake, err := sonyflakeParserFromFlags(*sonyStart, *sonyMachine)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	jwt, err := jwtParserFromFlags(*jwtSecret, *jwks)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	hashids, err := hashidsParserFromFlags(*hashSalt, *hashAlphabet, *hashMinLen, *hashProfile, *hashConfig)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Handle Git blob verification
+	if *gitBlob != "" {
+		handleGitBlob(*gitBlob, flag.Args())
+		return
+	}
 
 	// Handle ID generation
 	if *generate != "" {
-		handleGeneration(*generate)
+		handleGeneration(*generate, sonyflake, jwt, hashids)
 		return
 	}
 
@@ -45,11 +120,23 @@ func main() {
 	}
 
 	var input string
+	var inputs []string
 	if args[0] == "-" {
-		// Read from stdin
+		// Read from stdin; the structured formats take one ID per line, the others the first line
+		multiple := output.IsStructuredFormat(*outputFormat) && tmpl == nil
 		scanner := bufio.NewScanner(os.Stdin)
-		if scanner.Scan() {
-			input = strings.TrimSpace(scanner.Text())
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if !multiple {
+				input = line
+				break
+			}
+			if line != "" {
+				inputs = append(inputs, line)
+			}
+		}
+		if len(inputs) > 0 {
+			input = inputs[0]
 		}
 		if err := scanner.Err(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error reading from stdin: %v\n", err)
@@ -66,8 +153,31 @@ func main() {
 		os.Exit(1)
 	}
 
+	registry := parsers.NewRegistry()
+	registry.SetRevealSecrets(*reveal)
+	if jwt != nil {
+		registry.ReplaceParser(jwt)
+	}
+	if hashids != nil {
+		registry.ReplaceParser(hashids)
+	}
+	if sonyflake != nil {
+		registry.ReplaceParser(sonyflake)
+	}
+
+	// Handle cursor decoding
+	if *cursor {
+		handleCursor(registry, input, *outputFormat)
+		return
+	}
+
+	if len(inputs) > 1 {
+		handleStructuredLines(registry, inputs, *forceFormat, *outputFormat, *everything)
+		return
+	}
+
 	// Parse the ID
-	results := parsers.ParseID(input, *forceFormat)
+	results := registry.ParseID(input, *forceFormat)
 
 	if len(results) == 0 {
 		fmt.Fprintf(os.Stderr, "Error: Unable to parse ID '%s'\n", input)
@@ -76,13 +186,37 @@ func main() {
 			fmt.Fprintf(os.Stderr, "Try without the -f flag for auto-detection.\n")
 		} else {
 			fmt.Fprintf(os.Stderr, "The ID format is not recognized or supported.\n")
-			fmt.Fprintf(os.Stderr, "Supported formats: UUID, ULID, ObjectId, KSUID, Xid, CUID, SCRU128, TSID, NUID, NanoID, Snowflake, UnixTime, HashHex, Base58, PushID, Base32, ShortUUID, Sqids, TypeID\n")
+			fmt.Fprintf(os.Stderr, "Supported formats: UUID, ULID, ObjectId, KSUID, Xid, CUID, SCRU128, TSID, NUID, NanoID, Sonyflake, Snowflake, UnixTime, HashHex, Base58, PushID, Base32, Base32Hex, ZBase32, ShortUUID, Sqids, TypeID, Elasticsearch, Base58Check, Bech32, Ethereum, CID, GitOID, AWSARN, AWSResourceID, Azure, GCP, Prefixed, GitHubNodeID, JWT, IBAN, PaymentCard, IMEI, ISBN, EAN, Crockford, Geohash, Base64, Hashids\n")
 			fmt.Fprintf(os.Stderr, "Try using -f to force a specific format.\n")
 		}
 		os.Exit(1)
 	}
 
 	// Handle different output modes
+	if tmpl != nil {
+		// With -e every interpretation is rendered, otherwise the best match
+		if !*everything {
+			results = results[:1]
+		}
+		for _, result := range results {
+			if err := output.ShowTemplate(tmpl, result); err != nil {
+				fmt.Fprintf(os.Stderr, "Error rendering template: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
+	if output.IsStructuredFormat(*outputFormat) {
+		// With -e every interpretation is written, otherwise the best match
+		if err := output.ShowStructured(*outputFormat, results, *everything); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating %s output: %v\n", *outputFormat, err)
+			fmt.Fprintf(os.Stderr, "This is likely due to invalid data in the parsed result.\n")
+			os.Exit(1)
+		}
+		return
+	}
+
 	if *everything {
 		output.ShowEverything(results)
 		return
@@ -105,24 +239,45 @@ func main() {
 		}
 	case "short":
 		output.ShowShort(result)
-	case "json":
-		jsonOutput, err := json.MarshalIndent(result, "", "  ")
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
-			fmt.Fprintf(os.Stderr, "This is likely due to invalid data in the parsed result.\n")
-			os.Exit(1)
-		}
-		fmt.Println(string(jsonOutput))
 	case "binary":
 		output.ShowBinary(result)
 	default:
 		fmt.Fprintf(os.Stderr, "Error: Unknown output format '%s'\n", *outputFormat)
-		fmt.Fprintf(os.Stderr, "Supported formats: card, short, json, binary\n")
+		fmt.Fprintf(os.Stderr, "Supported formats: card, short, json, yaml, toml, csv, tsv, ndjson, logfmt, binary, template=TEXT\n")
+		os.Exit(1)
+	}
+}
+
+// handleStructuredLines writes the IDs read from stdin as one structured document, with the best
+// match of each (or every interpretation with -e). Lines that do not parse are reported on stderr
+// and make the exit status 1.
+func handleStructuredLines(registry *parsers.Registry, inputs []string, forceFormat, outputFormat string, everything bool) {
+	var results []*types.IDInfo
+	failed := false
+	for _, input := range inputs {
+		parsed := registry.ParseID(input, forceFormat)
+		if len(parsed) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: Unable to parse ID '%s'\n", input)
+			failed = true
+			continue
+		}
+		if !everything {
+			parsed = parsed[:1]
+		}
+		results = append(results, parsed...)
+	}
+	if len(results) > 0 {
+		if err := output.ShowStructured(outputFormat, results, true); err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating %s output: %v\n", outputFormat, err)
+			os.Exit(1)
+		}
+	}
+	if failed {
 		os.Exit(1)
 	}
 }
 
-func handleGeneration(format string) {
+func handleGeneration(format string, sonyflake *parsers.SonyflakeParser, jwt *parsers.JWTParser, hashids *parsers.HashidsParser) {
 	// Check if this is a UUID with version specification (e.g., "uuid:v1")
 	if strings.HasPrefix(strings.ToLower(format), "uuid:") {
 		parts := strings.SplitN(format, ":", 2)
@@ -138,9 +293,49 @@ func handleGeneration(format string) {
 		}
 	}
 
+	// Base32 variants (e.g., "base32:hex", "base32:zbase32", "base32:geohash")
+	if strings.HasPrefix(strings.ToLower(format), "base32:") {
+		variant := strings.SplitN(format, ":", 2)[1]
+		id, err := parsers.GenerateBase32(variant)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating Base32 %s: %v\n", variant, err)
+			os.Exit(1)
+		}
+		fmt.Println(id)
+		return
+	}
+
+	// Hashids of given numbers (e.g., "hashids:1,2,3")
+	if strings.HasPrefix(strings.ToLower(format), "hashids:") {
+		numbers, err := parseNumberList(strings.SplitN(format, ":", 2)[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if hashids == nil {
+			hashids = &parsers.HashidsParser{}
+		}
+		id, err := hashids.Encode(numbers)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating Hashids: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(id)
+		return
+	}
+
 	// Use existing parser for other formats or plain "uuid"
 	registry := parsers.NewRegistry()
 	parser := registry.GetParser(format)
+	if sonyflake != nil && strings.EqualFold(format, "sonyflake") {
+		parser = sonyflake
+	}
+	if jwt != nil && strings.EqualFold(format, "jwt") {
+		parser = jwt
+	}
+	if hashids != nil && strings.EqualFold(format, "hashids") {
+		parser = hashids
+	}
 	if parser == nil {
 		fmt.Fprintf(os.Stderr, "Error: Unsupported format '%s'\n", format)
 		fmt.Fprintf(os.Stderr, "Supported formats: ")
@@ -152,6 +347,8 @@ func handleGeneration(format string) {
 			fmt.Fprintf(os.Stderr, "%s", name)
 		}
 		fmt.Fprintf(os.Stderr, "\nFor UUID, you can also specify version: uuid:v1, uuid:v3, uuid:v4, uuid:v5, uuid:v6, uuid:v7\n")
+		fmt.Fprintf(os.Stderr, "For Base32, you can also specify the alphabet: base32:std, base32:hex, base32:zbase32, base32:geohash\n")
+		fmt.Fprintf(os.Stderr, "For Hashids, you can encode numbers: hashids:1,2,3\n")
 		os.Exit(1)
 	}
 
@@ -164,6 +361,571 @@ func handleGeneration(format string) {
 	fmt.Println(id)
 }
 
+// templateFlag compiles the template given by -o template=TEXT or --template-file, or returns nil
+// for the other output formats
+func templateFlag(outputFormat, templateFile string) *template.Template {
+	text, inline := strings.CutPrefix(outputFormat, "template=")
+	if !inline && templateFile == "" {
+		return nil
+	}
+	if templateFile != "" && outputFormat != "card" {
+		fmt.Fprintf(os.Stderr, "Error: --template-file replaces -o; drop -o %s\n", outputFormat)
+		os.Exit(1)
+	}
+
+	var tmpl *template.Template
+	var err error
+	if inline {
+		tmpl, err = output.ParseTemplate(text)
+	} else {
+		tmpl, err = output.ParseTemplateFile(templateFile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Invalid template: %v\n", err)
+		os.Exit(1)
+	}
+	return tmpl
+}
+
+// handleCursor decodes a pagination cursor or opaque token and shows the IDs inside it
+func handleCursor(registry *parsers.Registry, input, outputFormat string) {
+	node, err := registry.DecodeCursor(input)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: Unable to decode cursor '%s': %v\n", input, err)
+		os.Exit(1)
+	}
+
+	switch outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(node, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowCursor(node)
+	}
+}
+
+// handleGitBlob recomputes a file's Git blob object IDs and optionally compares them to an expected ID
+func handleGitBlob(path string, args []string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading file '%s': %v\n", path, err)
+		os.Exit(1)
+	}
+
+	sha1ID := parsers.GitBlobObjectID(content, "sha1")
+	sha256ID := parsers.GitBlobObjectID(content, "sha256")
+	fmt.Printf("SHA-1:   %s\n", sha1ID)
+	fmt.Printf("SHA-256: %s\n", sha256ID)
+
+	if len(args) == 0 {
+		return
+	}
+
+	match, err := parsers.MatchGitBlob(content, args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if match {
+		fmt.Printf("Match: %s is the blob ID of %s\n", args[0], path)
+		return
+	}
+	fmt.Printf("Mismatch: %s is not the blob ID of %s\n", args[0], path)
+	os.Exit(1)
+}
+
+// handleCollisionCommand estimates collision risk from random bits, an alphabet and length, or an ID
+func handleCollisionCommand(args []string) {
+	fs := flag.NewFlagSet("collision", flag.ExitOnError)
+	bits := fs.Int("bits", 0, "Random bits per ID")
+	alphabetSize := fs.Int("alphabet-size", 0, "Alphabet size, with --length")
+	length := fs.Int("length", 0, "ID length in characters, with --alphabet-size")
+	rate := fs.String("rate", "", "Generation rate, e.g. 1000/s, 50/min, 2e6/day")
+	count := fs.Float64("count", 0, "Total number of IDs generated")
+	period := fs.String("period", "1y", "Period the rate runs for when --count is not given")
+	threshold := fs.String("threshold", "1%", "Collision risk to report capacity for")
+	forceFormat := fs.String("f", "", "Force parsing the ID as a specific format")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	positional := parseFlagsAnywhere(fs, args)
+	if len(positional) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: Give one ID, not %d\n", len(positional))
+		os.Exit(1)
+	}
+
+	randomBits, source := *bits, ""
+	var tick *parsers.TimestampTick
+	switch {
+	case *bits > 0:
+	case *alphabetSize > 1 && *length > 0:
+		randomBits = int(float64(*length) * math.Log2(float64(*alphabetSize)))
+		source = fmt.Sprintf("%d characters from %d symbols", *length, *alphabetSize)
+	case len(positional) > 0:
+		results := parsers.ParseID(positional[0], *forceFormat)
+		if len(results) == 0 || results[0].Entropy == nil {
+			fmt.Fprintf(os.Stderr, "Error: Unable to determine the random bits of '%s'\n", positional[0])
+			os.Exit(1)
+		}
+		randomBits, source = *results[0].Entropy, results[0].IDType
+		if results[0].DateTime != nil {
+			// Time-based IDs only collide within one timestamp tick
+			t, ok := parsers.TimestampTickOf(results[0])
+			if !ok && (*rate != "" || *count > 0) {
+				fmt.Fprintf(os.Stderr, "Error: %s IDs only collide within one timestamp tick, and its length is unknown; use --bits for a per-tick estimate\n", results[0].IDType)
+				os.Exit(1)
+			}
+			if ok && *count > 0 && *rate == "" {
+				fmt.Fprintf(os.Stderr, "Error: %s IDs only collide within one %s; give --rate so the IDs can be spread over ticks\n", results[0].IDType, t.Label)
+				os.Exit(1)
+			}
+			if ok {
+				tick = &t
+			}
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "Error: Give --bits, --alphabet-size with --length, or an ID\n")
+		fmt.Fprintf(os.Stderr, "Usage: %s collision [--bits N | --alphabet-size N --length N | <ID>] [--rate R] [--count N] [--threshold P]\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	p, err := parsers.ParseCollisionThreshold(*threshold)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	var perSecond, periodSeconds float64
+	if *rate != "" {
+		if perSecond, err = parsers.ParseCollisionRate(*rate); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if periodSeconds, err = parsers.ParseCollisionPeriod(*period); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	var risk *types.CollisionRisk
+	if tick != nil {
+		risk = parsers.EstimateTickCollision(randomBits, p, *count, perSecond, periodSeconds, *tick)
+	} else {
+		risk = parsers.EstimateCollision(randomBits, p, *count, perSecond, periodSeconds)
+	}
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(risk, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowCollision(risk, source)
+	}
+}
+
+// parseFlagsAnywhere parses a subcommand's flags wherever they appear, so flags after a positional
+// argument are not silently taken as more arguments, and returns the positional arguments
+func parseFlagsAnywhere(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		rest := fs.Args()
+		if len(rest) == 0 {
+			return positional
+		}
+		// Everything after "--" is positional
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			return append(positional, rest...)
+		}
+		positional = append(positional, rest[0])
+		args = rest[1:]
+	}
+}
+
+// handleAnalyzeCommand measures the randomness of many IDs read one per line from a file or stdin
+func handleAnalyzeCommand(args []string) {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	forceFormat := fs.String("f", "", "Treat every ID as this format")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	analysis, err := parsers.NewRegistry().AnalyzeCorpus(readIDLines(fs.Args()), *forceFormat)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(analysis, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowAnalysis(analysis)
+	}
+}
+
+// handleFleetCommand reports the generators (machines, processes, nodes) seen in a stream of IDs
+func handleFleetCommand(args []string) {
+	fs := flag.NewFlagSet("fleet", flag.ExitOnError)
+	forceFormat := fs.String("f", "", "Treat every ID as this format")
+	skew := fs.Duration("skew-threshold", time.Second, "Clock offset from the fleet that is reported as drift")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	report, err := parsers.NewRegistry().InferFleet(readIDLines(fs.Args()), *forceFormat, *skew)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowFleet(report)
+	}
+}
+
+// handleCheckCommand checks a stream of IDs for anomalies and exits with status 1 when it finds any,
+// so it can gate a data pipeline
+func handleCheckCommand(args []string) {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	forceFormat := fs.String("f", "", "Treat every ID as this format")
+	tolerance := fs.Duration("future-tolerance", time.Minute, "How far in the future a timestamp may be")
+	reveal := fs.Bool("reveal-secrets", false, "Show API keys and card numbers unmasked")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	registry := parsers.NewRegistry()
+	registry.SetRevealSecrets(*reveal)
+	report, err := registry.CheckStream(readIDLines(fs.Args()), *forceFormat, time.Now(), *tolerance)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowAnomalies(report)
+	}
+	if len(report.Findings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// handleDiffCommand compares two or more IDs field by field against the first one
+func handleDiffCommand(args []string) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	forceFormat := fs.String("f", "", "Parse every ID as this format")
+	reveal := fs.Bool("reveal-secrets", false, "Show API keys and card numbers unmasked")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fmt.Fprintf(os.Stderr, "Error: diff needs at least 2 IDs\n")
+		os.Exit(1)
+	}
+
+	registry := parsers.NewRegistry()
+	registry.SetRevealSecrets(*reveal)
+	var infos []*types.IDInfo
+	for _, arg := range fs.Args() {
+		results := registry.ParseID(strings.TrimSpace(arg), *forceFormat)
+		if len(results) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: unable to parse ID '%s'\n", arg)
+			os.Exit(1)
+		}
+		infos = append(infos, results[0])
+	}
+	diff, err := parsers.DiffIDs(infos)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowDiff(diff)
+	}
+}
+
+// handleSortCommand orders IDs by their embedded timestamp. Sorting needs the whole input, but
+// IDs outside the --since/--until window are dropped as they are read.
+func handleSortCommand(args []string) {
+	fs := flag.NewFlagSet("sort", flag.ExitOnError)
+	forceFormat := fs.String("f", "", "Treat every ID as this format")
+	since := fs.String("since", "", "Only IDs created at or after this time")
+	until := fs.String("until", "", "Only IDs created before this time")
+	tz := fs.String("tz", "UTC", "Time zone for display and for times without an offset")
+	reverse := fs.Bool("r", false, "Newest first")
+	reveal := fs.Bool("reveal-secrets", false, "Show API keys and card numbers unmasked")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	loc := timeZoneFlag(*tz)
+	registry := parsers.NewRegistry()
+	registry.SetRevealSecrets(*reveal)
+	timeline := registry.NewTimeline(*forceFormat, timeFlag("since", *since, loc), timeFlag("until", *until, loc))
+	var ids []*types.TimedID
+	scanIDLines(fs.Args(), func(line string) {
+		if id := timeline.Add(line); id != nil {
+			ids = append(ids, id)
+		}
+	})
+	parsers.SortByTime(ids, *reverse)
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(ids, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowTimeline(ids)
+	}
+	if timeline.Skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%d lines without a timestamp skipped\n", timeline.Skipped)
+	}
+}
+
+// handleHistogramCommand counts IDs per minute, hour or day of their embedded timestamp; only the
+// bucket counts are kept, so it works on inputs of any size
+func handleHistogramCommand(args []string) {
+	fs := flag.NewFlagSet("histogram", flag.ExitOnError)
+	forceFormat := fs.String("f", "", "Treat every ID as this format")
+	bucket := fs.String("bucket", "hour", "Bucket size (minute, hour, day)")
+	since := fs.String("since", "", "Only IDs created at or after this time")
+	until := fs.String("until", "", "Only IDs created before this time")
+	tz := fs.String("tz", "UTC", "Time zone for buckets, display and times without an offset")
+	width := fs.Int("width", 60, "Width of the longest bar")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	loc := timeZoneFlag(*tz)
+	histogram, err := parsers.NewHistogram(*bucket, loc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	timeline := parsers.NewRegistry().NewTimeline(*forceFormat, timeFlag("since", *since, loc), timeFlag("until", *until, loc))
+	scanIDLines(fs.Args(), func(line string) {
+		if id := timeline.Add(line); id != nil {
+			histogram.Add(id.DateTime)
+		}
+	})
+	result, err := histogram.Result()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	result.Skipped, result.Filtered = timeline.Skipped, timeline.Filtered
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowHistogram(result, max(*width, 1))
+	}
+}
+
+// handlePrefixCommand shows what IDs of each time-sortable format look like at an instant
+func handlePrefixCommand(args []string) {
+	fs := flag.NewFlagSet("prefix", flag.ExitOnError)
+	at := fs.String("at", "now", "The instant to look up")
+	tz := fs.String("tz", "UTC", "Time zone for display and for times without an offset")
+	outputFormat := fs.String("o", "text", "Output format (text, json)")
+	fs.Parse(args)
+
+	value := *at
+	if fs.NArg() > 0 {
+		value = strings.Join(fs.Args(), " ")
+	}
+	report := parsers.TimePrefixes(*timeFlag("at", value, timeZoneFlag(*tz)))
+
+	switch *outputFormat {
+	case "json":
+		jsonOutput, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating JSON output: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(jsonOutput))
+	default:
+		output.ShowTimePrefixes(report)
+	}
+}
+
+// timeFlag parses an optional time-accepting option such as --since, exiting on a malformed value
+func timeFlag(name, value string, loc *time.Location) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := parsers.ParseHumanTime(value, time.Now(), loc)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --%s: %v\n", name, err)
+		os.Exit(1)
+	}
+	return &t
+}
+
+// timeZoneFlag resolves --tz and makes it the display time zone, exiting on an unknown zone
+func timeZoneFlag(name string) *time.Location {
+	loc, err := parsers.ParseTimeZone(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: --tz: %v\n", err)
+		os.Exit(1)
+	}
+	output.SetTimeZone(loc)
+	return loc
+}
+
+// readIDLines reads one ID per line from the file named by the first argument, or from stdin
+// when there is none or it is "-"
+func readIDLines(args []string) []string {
+	var lines []string
+	scanIDLines(args, func(line string) {
+		lines = append(lines, line)
+	})
+	return lines
+}
+
+// scanIDLines calls fn for each line of the file named by the first argument, or of stdin when
+// there is none or it is "-", without holding the input in memory
+func scanIDLines(args []string, fn func(line string)) {
+	in := os.Stdin
+	if len(args) > 0 && args[0] != "-" {
+		file, err := os.Open(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		defer file.Close()
+		in = file
+	}
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		fn(scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading IDs: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// sonyflakeParserFromFlags builds a Sonyflake parser when a custom start time or machine ID is given
+func sonyflakeParserFromFlags(start string, machine int) (*parsers.SonyflakeParser, error) {
+	if start == "" && machine < 0 {
+		return nil, nil
+	}
+
+	var settings parsers.SonyflakeSettings
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", start)
+			if err != nil {
+				return nil, fmt.Errorf("invalid sonyflake start time '%s' (use RFC3339 or YYYY-MM-DD)", start)
+			}
+		}
+		settings.StartTime = t
+	}
+	if machine >= 0 {
+		if machine > 0xffff {
+			return nil, fmt.Errorf("sonyflake machine ID %d is out of range (0-65535)", machine)
+		}
+		machineID := uint16(machine)
+		settings.MachineID = &machineID
+	}
+
+	return parsers.NewSonyflakeParser(settings), nil
+}
+
+// jwtParserFromFlags builds a JWT parser when a secret or JWKS file is given for signature verification
+func jwtParserFromFlags(secret, jwksFile string) (*parsers.JWTParser, error) {
+	if secret == "" && jwksFile == "" {
+		return nil, nil
+	}
+	return parsers.NewJWTParser(parsers.JWTSettings{Secret: secret, JWKSFile: jwksFile})
+}
+
+// hashidsParserFromFlags builds a Hashids parser from a profile and/or explicit salt, alphabet and
+// minimum length; explicit flags override the profile's values
+func hashidsParserFromFlags(salt, alphabet string, minLength int, profile, configPath string) (*parsers.HashidsParser, error) {
+	if salt == "" && alphabet == "" && minLength < 0 && profile == "" {
+		return nil, nil
+	}
+
+	var settings parsers.HashidsSettings
+	if profile != "" {
+		if configPath == "" {
+			configPath = parsers.DefaultHashidsConfigPath()
+		}
+		var err error
+		settings, err = parsers.LoadHashidsProfile(configPath, profile)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if salt != "" {
+		settings.Salt = salt
+	}
+	if alphabet != "" {
+		settings.Alphabet = alphabet
+	}
+	if minLength >= 0 {
+		settings.MinLength = minLength
+	}
+	return parsers.NewHashidsParser(settings)
+}
+
+// parseNumberList parses a comma-separated list of non-negative integers
+func parseNumberList(list string) ([]uint64, error) {
+	var numbers []uint64
+	for _, field := range strings.Split(list, ",") {
+		n, err := strconv.ParseUint(strings.TrimSpace(field), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s' (use a comma-separated list such as 1,2,3)", field)
+		}
+		numbers = append(numbers, n)
+	}
+	return numbers, nil
+}
+
 func generateUUIDWithVersion(version string) (string, error) {
 	switch version {
 	case "v1":
@@ -219,28 +981,174 @@ USAGE:
     idinfo [OPTIONS] <ID>
     idinfo [OPTIONS] -
     idinfo -g <FORMAT>
+    idinfo collision [--bits N | --alphabet-size N --length N | <ID>] [OPTIONS]
+    idinfo analyze [-f FORMAT] [-o text|json] [FILE | -]
+    idinfo fleet [-f FORMAT] [--skew-threshold 1s] [-o text|json] [FILE | -]
+    idinfo check [-f FORMAT] [--future-tolerance 1m] [-o text|json] [FILE | -]
+    idinfo diff [-f FORMAT] [-o text|json] <ID> <ID>...
+    idinfo sort [-f FORMAT] [--since T] [--until T] [--tz ZONE] [-r] [-o text|json] [FILE | -]
+    idinfo histogram [-f FORMAT] [--bucket minute|hour|day] [--since T] [--until T]
+                     [--tz ZONE] [--width N] [-o text|json] [FILE | -]
+    idinfo prefix [--at TIME | TIME] [--tz ZONE] [-o text|json]
 
 OPTIONS:
     -f <FORMAT>     Force parsing as specific format
                     Available formats: uuid, ulid, objectid, ksuid, xid, cuid,
-                    scru128, tsid, nuid, nanoid, snowflake, base58, pushid,
-                    base32, shortuuid,
-                    sqids, typeid, etc.
-    -o <OUTPUT>     Output format (card, short, json, binary) [default: card]
+                    scru128, tsid, nuid, nanoid, snowflake, sonyflake, base58, pushid,
+                    base32, base32hex, zbase32, shortuuid,
+                    sqids, typeid, elasticsearch, base58check, bech32,
+                    ethereum, cid, gitoid, awsarn, awsresourceid, azure,
+                    gcp, prefixed, github, jwt, iban, card, imei, isbn,
+                    ean, upc, crockford, geohash, base64, hashids, etc.
+    -o <OUTPUT>     Output format (card, short, json, yaml, toml, csv, tsv, ndjson,
+                    logfmt, binary, template=TEXT) [default: card]; with -e, the
+                    structured formats write every interpretation, and with -
+                    they read one ID per line from stdin
+    --template-file <FILE>
+                    Render the result through a Go template kept in a file
     -e              Show all possible format interpretations
     -g <FORMAT>     Generate new ID of specified format
                     For UUID, you can specify version: uuid:v1, uuid:v3, uuid:v4, 
                     uuid:v5, uuid:v6, uuid:v7 (default is v4)
+                    For Base32, you can specify the alphabet: base32:std,
+                    base32:hex, base32:zbase32, base32:geohash
+                    For Hashids, you can encode numbers: hashids:1,2,3
     --color         Enable colored output [default: true]
+    --git-blob <FILE>
+                    Compute the Git blob object ID (SHA-1 and SHA-256) of a file;
+                    pass an ID argument (at least 4 hex characters) to verify it
+    --sonyflake-start <TIME>
+                    Sonyflake start time (RFC3339 or YYYY-MM-DD) [default: 2014-09-01]
+    --sonyflake-machine <ID>
+                    Sonyflake machine ID (0-65535) [default: private IPv4 lower 16 bits]
+    --jwt-secret <SECRET>
+                    HMAC secret for verifying HS256/384/512 JWT signatures
+    --jwks <FILE>   Local JWKS file for verifying JWT signatures (oct, RSA, EC, Ed25519 keys)
+    --reveal-secrets
+                    Show API keys and card numbers unmasked
+    --tz <ZONE>     Time zone timestamps are shown in: an IANA name such as
+                    Europe/Berlin, UTC or local [default: UTC]; JSON stays in UTC
+    --hashids-salt <SALT>
+                    Hashids salt for parsing and generation
+    --hashids-alphabet <ALPHABET>
+                    Hashids alphabet [default: a-z, A-Z, 0-9]
+    --hashids-min-length <N>
+                    Hashids minimum length [default: 0]
+    --hashids-profile <NAME>
+                    Load salt, alphabet and min_length from a named profile
+    --hashids-config <FILE>
+                    Hashids profiles file [default: <config dir>/idinfo/hashids.json]
     --compare       Compare timestamps from different format interpretations
+    --cursor        Decode a pagination cursor or opaque token (base64, gzip, zlib,
+                    JSON, msgpack, protobuf) and show the IDs inside it
     --help          Show this help message
 
+COLLISION OPTIONS:
+    --bits <N>      Random bits per ID (or give --alphabet-size and --length, or an ID)
+    --rate <RATE>   Generation rate, e.g. 1000/s, 50/min, 2e6/day
+    --count <N>     Total number of IDs generated (needs --rate for time-based IDs)
+    --period <P>    How long the rate runs when --count is not given [default: 1y]
+                    Time-based IDs only collide within one timestamp tick: capacity
+                    is per tick, and the rate is spread over the ticks of the period
+    --threshold <P> Collision risk to report capacity and time for [default: 1%]
+    -o <OUTPUT>     Output format (text, json) [default: text]
+
+ANALYZE:
+    Reads one ID per line and measures their actual randomness: per-bit frequency
+    and runs tests, chi-square per character position and Shannon entropy estimates,
+    compared against the format's declared entropy. Without -f, every ID is parsed
+    as the format of the first one.
+
+FLEET:
+    Groups IDs that embed machine, process or node components (ObjectId, Xid,
+    UUIDv1, Snowflake, Sonyflake, NUID) by generator and reports each one's ID
+    count, first and last seen times, rate, counter gaps and resets. When the
+    input is in arrival order, generators whose clock is off from the rest by
+    more than --skew-threshold are reported.
+
+CHECK:
+    Checks a stream of IDs for duplicates, lines that do not parse, timestamps in
+    the future or at or before the format's epoch, clocks going backwards and
+    counters wrapping or going back within one generator, and ULIDs that are not
+    monotonic within a millisecond. Findings carry line numbers; the exit status
+    is 1 when there are any, so it can gate an ETL job.
+
+DIFF:
+    Parses each ID and lines up their fields and details, marking the ones that
+    differ with ≠. Every ID is related to the first one: time delta, same or
+    different machine and process, counter distance, and the bits that differ
+    in the hex/binary view.
+
+SORT AND HISTOGRAM:
+    Decode each ID's embedded timestamp. sort prints the IDs in creation order;
+    histogram counts them per minute, hour or day and draws a bar per bucket,
+    keeping empty buckets so quiet periods show. Both drop IDs outside
+    --since/--until (until exclusive) as they read, and histogram keeps only
+    the counts, so it handles inputs of any size. --tz sets the zone of day and
+    hour buckets and of the times shown.
+
+PREFIX:
+    Shows what a ULID, ObjectId, UUIDv7, KSUID, Xid and TSID created at an
+    instant (default now) looks like: the prefix all of them share, for
+    LIKE 'prefix%' queries and log greps, and the first and last possible ID,
+    for BETWEEN queries. Where the timestamp does not end on a character
+    boundary the prefix also matches neighbouring ticks; the span it matches
+    is shown with it.
+
+TEMPLATES:
+    -o template=TEXT and --template-file render the result with Go's
+    text/template; fields have the Go names of the JSON keys (.IDType,
+    .DateTime, .Integer, .Hex, .Binary, index .Extra "key", ...). Helpers:
+    rfc3339, rfc3339ms, formatTime LAYOUT, unix, unixMilli, age (times, in
+    --tz), hex, hexGroup N, base N (2-62), default VALUE, upper, lower,
+    join SEP, percent and json. With -e every interpretation is rendered.
+
+TIMES:
+    Options that take a time (--at, --since, --until) accept RFC3339,
+    YYYY-MM-DD[ HH:MM[:SS]], now, today, yesterday 14:00, relative offsets
+    (2h ago, in 30m, -3d, +1w), Unix epochs in s, ms, us or ns (chosen by
+    magnitude, optionally @-prefixed) and ISO weeks (2024-W09, 2024-W09-3).
+    Times without an offset are read in --tz.
+
 EXAMPLES:
     Parse ID:
       idinfo 01941f29-7c00-7aaa-aaaa-aaaaaaaaaaaa
       idinfo -f uuid 01941f29-7c00-7aaa-aaaa-aaaaaaaaaaaa
       idinfo -o json 01HVZ7JKJJ8M9K9M9M9M9M9M9M
+      idinfo -o 'template={{.IDType}} {{.DateTime | rfc3339}}' 01HVZ7JKJJ8M9K9M9M9M9M9M9M
+      idinfo -e -o csv 507f1f77bcf86cd799439011
+      psql -Atc "select id from orders" | idinfo -o ndjson -
       echo "01941f29-7c00-7aaa-aaaa-aaaaaaaaaaaa" | idinfo -
+      idinfo --jwt-secret "$SECRET" eyJhbGciOiJIUzI1NiJ9.eyJzdWIiOiIxMjMifQ.c2ln
+      idinfo --git-blob empty.txt e69de29bb2d1
+      idinfo --cursor eyJpZCI6IjY0ZDQ5MDkzMWM0M2FmNjU5ZGY1MGU2NiJ9
+
+    Analyze a corpus of IDs:
+      idinfo analyze ids.txt
+      psql -Atc "select id from orders" | idinfo analyze -f uuid -o json -
+
+    Count the pods behind a set of records:
+      psql -Atc "select _id from events order by inserted_at" | idinfo fleet -
+
+    Gate a data load on ID quality:
+      idinfo check -o json exported_ids.txt > findings.json || exit 1
+
+    Tell two IDs from one incident apart:
+      idinfo diff 5f1d7f3e0000000000000001 5f1d7f3e0000000000000002
+
+    Put an incident dump in creation order:
+      idinfo sort --since 2024-03-01T10:00:00Z --until 2024-03-01T12:00:00Z ids.txt
+      idinfo histogram --bucket minute - < ids.txt
+      idinfo sort --tz Europe/Berlin --since "yesterday 14:00" --until "2h ago" ids.txt
+
+    Find the IDs created at a given time:
+      idinfo prefix --at "2024-03-01 10:00"
+      idinfo prefix -o json 2h ago
+
+    Estimate collision risk:
+      idinfo collision --alphabet-size 64 --length 12 --rate 1000/s
+      idinfo collision --bits 80 --count 1e9
+      idinfo collision --rate 50000/s 01ARZ3NDEKTSV4RRFFQ69G5FAV
 
     Generate ID:
       idinfo -g uuid         # Generate UUID v4 (random)
@@ -252,15 +1160,31 @@ EXAMPLES:
       idinfo -g uuid:v7      # Generate UUID v7 (sortable timestamp + random)
       idinfo -g ulid
       idinfo -g objectid
+      idinfo -g sonyflake --sonyflake-machine 42 --sonyflake-start 2020-01-01
+      idinfo -g elasticsearch
+      idinfo -g base32:zbase32
 
 SUPPORTED ID FORMATS:
     - UUID (v1-v8), ULID, MongoDB ObjectId
-    - KSUID, Xid, CUID2, SCRU128, TSID, NUID
-    - Snowflake variants (Twitter, Discord, etc.)
+    - KSUID, Xid, CUID (v1 and CUID2), SCRU128, TSID, NUID
+    - Snowflake variants (Twitter, Discord, etc.), Sonyflake
     - NanoID, Firebase PushID
-    - Base58 (Bitcoin-style), Base32, Unix timestamps
+    - Base58 (Bitcoin-style), Base32 (RFC 4648, base32hex, z-base-32),
+      Crockford Base32 with check symbol, Geohash
+    - Timestamps (Unix s/ms/us/ns, negative, fractional or hex, FILETIME,
+      .NET ticks, WebKit, Cocoa, GPS, NTP, Excel), with every reading scored
+    - Base64/Base64URL, with embedded ObjectId, UUID/ULID and KSUID payloads
+    - Content identifiers (IPFS CIDv0/v1, multihash, Git object IDs)
+    - Cloud resource IDs (AWS ARNs and resource IDs, Azure resource IDs, GCP)
+    - Platform IDs and API keys (Stripe, Slack, GitHub node IDs and tokens,
+      OpenAI, Anthropic, AWS access keys); secrets are masked in all output
+    - Check-digit identifiers (IBAN, ISBN-10/13, EAN-13/EAN-8/UPC-A, IMEI,
+      payment card numbers); card numbers are masked
+    - JSON Web Tokens (claims, timestamps, nested IDs, signature verification)
+    - Cryptocurrency addresses (Base58Check, Bech32/Bech32m SegWit, Ethereum EIP-55)
     - Hex-encoded hashes (MD5, SHA-1, SHA-256, etc.)
     - ShortUUID
-    - Sqids, TypeID (typed identifiers)
+    - Sqids, Hashids (with project salt), TypeID (typed identifiers)
+    - Elasticsearch/OpenSearch auto-generated document IDs
 `)
 }